@@ -0,0 +1,22 @@
+package protogetter
+
+import (
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzers returns the whole family of proto analyzers with the default configuration.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		NewAnalyzer(nil),
+		NewFieldMaskAnalyzer(nil),
+		NewHoistAnalyzer(nil),
+	}
+}
+
+// OptInAnalyzers returns proto analyzers, which must be enabled explicitly,
+// because they contradict the default rules (for example, the inverse of the getters check).
+func OptInAnalyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		NewDirectAccessAnalyzer(nil),
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// apiSummary prints the packages which still access fields of open and hybrid API messages directly,
+// and thus have to be migrated before the messages are switched to the opaque API.
+func apiSummary(w io.Writer, cfg *protogetter.Config, opts *options, patterns []string) error {
+	loadCfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedImports | packages.NeedDeps,
+		Tests: opts.Tests,
+	}
+
+	pkgs, err := packages.Load(loadCfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return fmt.Errorf("%d errors during loading", n)
+	}
+
+	type usage struct {
+		accesses map[protogetter.API]int
+		messages map[string]bool
+	}
+	byPath := make(map[string]*usage)
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		// Skip generated test main packages.
+		if strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+
+		files, err := protogetter.FilterFiles(pkg.Fset, pkg.Syntax, cfg)
+		if err != nil {
+			return err
+		}
+
+		u := byPath[pkg.PkgPath]
+		if u == nil {
+			u = &usage{accesses: make(map[protogetter.API]int), messages: make(map[string]bool)}
+			byPath[pkg.PkgPath] = u
+		}
+
+		for _, f := range files {
+			// Files of a package are also files of its test variant.
+			filename := pkg.Fset.File(f.Pos()).Name()
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+
+			ast.Inspect(f, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				selection, ok := pkg.TypesInfo.Selections[sel]
+				if !ok || selection.Kind() != types.FieldVal || !selection.Obj().Exported() {
+					return true
+				}
+
+				api, ok := protogetter.MessageAPI(selection.Recv())
+				if !ok || api == protogetter.APIOpaque {
+					return true
+				}
+
+				u.accesses[api]++
+				u.messages[types.TypeString(selection.Recv(), nil)] = true
+				return true
+			})
+		}
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	open := 0
+	for _, path := range paths {
+		u := byPath[path]
+		if len(u.messages) == 0 {
+			continue
+		}
+		open++
+
+		fmt.Fprintf(w, "%s: %d open, %d hybrid API field accesses of %s\n", path,
+			u.accesses[protogetter.APIOpen], u.accesses[protogetter.APIHybrid], plural(len(u.messages), "message"))
+	}
+
+	_, err = fmt.Fprintf(w, "%d of %s use the open struct API.\n", open, plural(len(paths), "package"))
+	return err
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"regexp"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// bestEffort prepares packages with errors (common in the middle of a refactoring) for the analysis instead of
+// aborting the run: type-checked parts of such packages are analyzed with partial type information,
+// and the returned files containing errors are skipped.
+//
+// The returned analyzer recovers from panics on packages with errors, since their type information may be
+// incomplete in unexpected ways.
+func (d *driver) bestEffort(pkgs []*packages.Package) (*analysis.Analyzer, map[string]bool) {
+	illTyped := make(map[*types.Package]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if !pkg.IllTyped || pkg.Types == nil || pkg.TypesInfo == nil {
+			return
+		}
+
+		illTyped[pkg.Types] = true
+		pkg.IllTyped = false
+	})
+
+	if len(illTyped) == 0 {
+		return d.analyzer, nil
+	}
+
+	errorFiles := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			if m := errorPosRe.FindStringSubmatch(err.Pos); m != nil {
+				errorFiles[m[1]] = true
+			}
+		}
+	}
+
+	analyzer := *d.analyzer
+	analyzer.Run = func(pass *analysis.Pass) (result any, err error) {
+		if illTyped[pass.Pkg] {
+			defer func() {
+				if r := recover(); r != nil {
+					fmt.Fprintf(os.Stderr, "%s: %s: analysis is incomplete due to errors in package: %v\n",
+						d.analyzer.Name, pass.Pkg.Path(), r)
+					result, err = nil, nil
+				}
+			}()
+		}
+
+		return d.analyzer.Run(pass)
+	}
+
+	return &analyzer, errorFiles
+}
+
+// errorPosRe matches the filename of the packages.Error position, which is "file:line:col" or "file:line".
+var errorPosRe = regexp.MustCompile(`^(.+?):\d+(?::\d+)?$`)
+
+// skipErrorFiles removes issues of the files with errors and reports these files as skipped.
+func (d *driver) skipErrorFiles(issues []issue, errorFiles map[string]bool) []issue {
+	if len(errorFiles) == 0 {
+		return issues
+	}
+
+	filenames := make([]string, 0, len(errorFiles))
+	for filename := range errorFiles {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		fmt.Fprintf(os.Stderr, "%s: %s: skipped due to errors\n", d.analyzer.Name, filename)
+	}
+
+	filtered := issues[:0]
+	for _, is := range issues {
+		if !errorFiles[is.pos.Filename] {
+			filtered = append(filtered, is)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// subcommands are the commands dispatched by main, with their descriptions for the completion.
+var subcommands = []struct {
+	name, usage string
+}{
+	{"explain", "print the rationale and examples of rules"},
+	{"config", "check the config file"},
+	{"corpus", "compare issues of repositories with recorded expectations"},
+	{"lsp", "run as a language server over stdio"},
+	{"completion", "print the shell completion script"},
+}
+
+// repeatedFlags are the flags which may be set several times.
+var repeatedFlags = []string{"skip-files", "skip-generated-by", "variant"}
+
+// fileFlags are the flags taking a path, the completion offers files for them.
+var fileFlags = []string{"config", "cpuprofile", "memprofile", "trace", "metrics", "new-from-patch", "stdin-filename"}
+
+// completion prints the completion script of the shell.
+func completion(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: protogetter completion bash|zsh|fish")
+		return exitError
+	}
+
+	fs := newFlagSet(protogetter.NewAnalyzer(nil), &options{})
+
+	var err error
+	switch args[0] {
+	case "bash":
+		err = completionBash(os.Stdout, fs)
+	case "zsh":
+		err = completionZsh(os.Stdout, fs)
+	case "fish":
+		err = completionFish(os.Stdout, fs)
+	default:
+		fmt.Fprintf(os.Stderr, "protogetter: unsupported shell %q, expected one of: bash, zsh, fish\n", args[0])
+		return exitError
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protogetter: %v\n", err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// completionFlag describes a flag for the completion scripts.
+type completionFlag struct {
+	name   string
+	usage  string
+	isBool bool
+	// values are the accepted values, files are completed if the flag takes a path.
+	values   []string
+	file     bool
+	repeated bool
+}
+
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		cf := completionFlag{
+			name:  f.Name,
+			usage: strings.Join(strings.Fields(f.Usage), " "),
+			file:  slices.Contains(fileFlags, f.Name),
+
+			repeated: slices.Contains(repeatedFlags, f.Name),
+		}
+
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			cf.isBool = true
+		}
+
+		switch f.Name {
+		case "format":
+			cf.values = formats
+		case "path-mode":
+			cf.values = pathModes
+		}
+
+		flags = append(flags, cf)
+	})
+
+	return flags
+}
+
+func ruleIDs() []string {
+	var ids []string
+	for _, r := range protogetter.Rules() {
+		ids = append(ids, r.ID)
+	}
+
+	return ids
+}
+
+func subcommandNames() []string {
+	var names []string
+	for _, c := range subcommands {
+		names = append(names, c.name)
+	}
+
+	return names
+}
+
+func completionBash(w io.Writer, fs *flag.FlagSet) error {
+	flags := completionFlags(fs)
+
+	var all, files []string
+	for _, f := range flags {
+		all = append(all, "-"+f.name)
+		if f.file {
+			files = append(files, "-"+f.name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for protogetter, load with: source <(protogetter completion bash)\n\n")
+	b.WriteString("_protogetter() {\n")
+	b.WriteString("\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\" flag=\"\"\n")
+	b.WriteString("\tCOMPREPLY=()\n\n")
+
+	b.WriteString("\tif [[ $COMP_CWORD -ge 2 ]]; then\n")
+	b.WriteString("\t\tcase \"${COMP_WORDS[1]}\" in\n")
+	fmt.Fprintf(&b, "\t\texplain) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(ruleIDs(), " "))
+	b.WriteString("\t\tcompletion) COMPREPLY=($(compgen -W \"bash zsh fish\" -- \"$cur\")); return ;;\n")
+	b.WriteString("\t\tconfig) COMPREPLY=($(compgen -W \"check\" -- \"$cur\")); return ;;\n")
+	b.WriteString("\t\tesac\n")
+	b.WriteString("\tfi\n\n")
+
+	b.WriteString("\t# COMP_WORDBREAKS splits -flag=value into three words.\n")
+	b.WriteString("\tif [[ $prev == \"=\" ]]; then\n")
+	b.WriteString("\t\tflag=\"${COMP_WORDS[COMP_CWORD-2]}\"\n")
+	b.WriteString("\telif [[ $cur == \"=\" ]]; then\n")
+	b.WriteString("\t\tflag=\"$prev\" cur=\"\"\n")
+	b.WriteString("\telif [[ $prev == -* ]]; then\n")
+	b.WriteString("\t\tflag=\"$prev\"\n")
+	b.WriteString("\tfi\n\n")
+
+	b.WriteString("\tcase \"$flag\" in\n")
+	for _, f := range flags {
+		if len(f.values) > 0 {
+			fmt.Fprintf(&b, "\t-%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", f.name, strings.Join(f.values, " "))
+		}
+	}
+	if len(files) > 0 {
+		fmt.Fprintf(&b, "\t%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", strings.Join(files, "|"))
+	}
+	b.WriteString("\tesac\n\n")
+
+	b.WriteString("\tif [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(&b, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(all, " "))
+	b.WriteString("\telif [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(&b, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\") $(compgen -d -- \"$cur\"))\n", strings.Join(subcommandNames(), " "))
+	b.WriteString("\telse\n")
+	b.WriteString("\t\tCOMPREPLY=($(compgen -d -- \"$cur\"))\n")
+	b.WriteString("\tfi\n")
+	b.WriteString("}\n\n")
+	b.WriteString("complete -o default -F _protogetter protogetter\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// zshEscape escapes the description for the option specs of _arguments and _describe.
+var zshEscape = strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`)
+
+func completionZsh(w io.Writer, fs *flag.FlagSet) error {
+	var b strings.Builder
+	b.WriteString("#compdef protogetter\n\n")
+	b.WriteString("# zsh completion for protogetter, load with: source <(protogetter completion zsh)\n\n")
+	b.WriteString("_protogetter() {\n")
+
+	b.WriteString("\tcase $words[2] in\n")
+	fmt.Fprintf(&b, "\texplain) (( CURRENT > 2 )) && { compadd %s; return }; ;;\n", strings.Join(ruleIDs(), " "))
+	b.WriteString("\tcompletion) (( CURRENT > 2 )) && { compadd bash zsh fish; return }; ;;\n")
+	b.WriteString("\tconfig) (( CURRENT > 2 )) && { compadd check; return }; ;;\n")
+	b.WriteString("\tesac\n\n")
+
+	b.WriteString("\tif (( CURRENT == 2 )) && [[ $PREFIX != -* ]]; then\n")
+	b.WriteString("\t\tlocal -a commands\n")
+	b.WriteString("\t\tcommands=(\n")
+	for _, c := range subcommands {
+		fmt.Fprintf(&b, "\t\t\t'%s:%s'\n", c.name, zshEscape.Replace(c.usage))
+	}
+	b.WriteString("\t\t)\n")
+	b.WriteString("\t\t_describe -t commands 'command' commands\n")
+	b.WriteString("\tfi\n\n")
+
+	b.WriteString("\t_arguments \\\n")
+	for _, f := range completionFlags(fs) {
+		desc := zshEscape.Replace(f.usage)
+		switch {
+		case f.isBool:
+			fmt.Fprintf(&b, "\t\t'-%s[%s]' \\\n", f.name, desc)
+		case len(f.values) > 0:
+			fmt.Fprintf(&b, "\t\t'-%s=[%s]:%s:(%s)' \\\n", f.name, desc, f.name, strings.Join(f.values, " "))
+		case f.file:
+			fmt.Fprintf(&b, "\t\t'-%s=[%s]:file:_files' \\\n", f.name, desc)
+		case f.repeated:
+			fmt.Fprintf(&b, "\t\t'*-%s=[%s]:%s: ' \\\n", f.name, desc, f.name)
+		default:
+			fmt.Fprintf(&b, "\t\t'-%s=[%s]:%s: ' \\\n", f.name, desc, f.name)
+		}
+	}
+	b.WriteString("\t\t'*:package:_files -/'\n")
+	b.WriteString("}\n\n")
+	b.WriteString("compdef _protogetter protogetter\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fishEscape escapes the description for single-quoted fish strings.
+var fishEscape = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func completionFish(w io.Writer, fs *flag.FlagSet) error {
+	var b strings.Builder
+	b.WriteString("# fish completion for protogetter, load with: protogetter completion fish | source\n\n")
+	b.WriteString("complete -c protogetter -f\n")
+
+	for _, c := range subcommands {
+		fmt.Fprintf(&b, "complete -c protogetter -n __fish_use_subcommand -a %s -d '%s'\n", c.name, fishEscape.Replace(c.usage))
+	}
+	fmt.Fprintf(&b, "complete -c protogetter -n '__fish_seen_subcommand_from explain' -a '%s'\n", strings.Join(ruleIDs(), " "))
+	b.WriteString("complete -c protogetter -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'\n")
+	b.WriteString("complete -c protogetter -n '__fish_seen_subcommand_from config' -a 'check'\n")
+	b.WriteString("complete -c protogetter -n 'not __fish_seen_subcommand_from explain completion config' -a '(__fish_complete_directories)'\n")
+
+	for _, f := range completionFlags(fs) {
+		fmt.Fprintf(&b, "complete -c protogetter -o %s", f.name)
+		switch {
+		case len(f.values) > 0:
+			fmt.Fprintf(&b, " -x -a '%s'", strings.Join(f.values, " "))
+		case f.file:
+			b.WriteString(" -r -F")
+		case !f.isBool:
+			b.WriteString(" -x")
+		}
+		fmt.Fprintf(&b, " -d '%s'\n", fishEscape.Replace(f.usage))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// defaultConfigFile is used if it exists in the current directory and -config is not set.
+const defaultConfigFile = ".protogetter.yaml"
+
+// The keys of the config file are the names of the analyzer flags, lists are given as YAML sequences:
+//
+//	skip-files:
+//	  - "*_mock.go"
+//	skip-any-generated: true
+//
+// Flags set on the command line override the keys of the file.
+
+// configFile returns the config file to load: the given one, or the default one if it exists.
+func configFile(filename string) string {
+	if filename != "" {
+		return filename
+	}
+
+	if _, err := os.Stat(defaultConfigFile); err == nil {
+		return defaultConfigFile
+	}
+
+	return ""
+}
+
+// configProblem is an error in the config file, reported with the line of the offending key.
+type configProblem struct {
+	line    int
+	message string
+}
+
+// loadConfig sets the analyzer flags from the config file, except the flags in the set ones,
+// and returns the problems found in the file.
+func loadConfig(filename string, analyzerFlags *flag.FlagSet, set map[string]bool) ([]configProblem, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if len(doc.Content) == 0 {
+		// Empty file.
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []configProblem{{root.Line, "expected a mapping of flag names to values"}}, nil
+	}
+
+	var problems []configProblem
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		f := analyzerFlags.Lookup(key.Value)
+		if f == nil {
+			problems = append(problems, configProblem{key.Line, fmt.Sprintf("unknown key %q", key.Value)})
+			continue
+		}
+
+		if set[f.Name] {
+			continue
+		}
+
+		for _, v := range configValues(value) {
+			if err := f.Value.Set(v); err != nil {
+				problems = append(problems, configProblem{key.Line, fmt.Sprintf("invalid value of %q: %v", key.Value, err)})
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// configValues returns the scalar value, or the values of the sequence, as they would be passed to the flag.
+func configValues(n *yaml.Node) []string {
+	if n.Kind != yaml.SequenceNode {
+		return []string{n.Value}
+	}
+
+	values := make([]string, 0, len(n.Content))
+	for _, item := range n.Content {
+		values = append(values, item.Value)
+	}
+
+	return values
+}
+
+// checkConfig reports invalid glob patterns and conflicting options of the effective configuration.
+func checkConfig(cfg *protogetter.Config) []string {
+	var problems []string
+
+	for _, pattern := range cfg.SkipFiles {
+		g, err := glob.Compile(strings.TrimSpace(pattern))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("skip-files: invalid glob pattern %q: %v", pattern, err))
+			continue
+		}
+
+		if g.Match("/src/pkg/file.go") && g.Match("/src/pkg/file_test.go") {
+			problems = append(problems, fmt.Sprintf("skip-files: pattern %q skips all files", pattern))
+		}
+	}
+
+	if cfg.SkipAnyGenerated && len(cfg.SkipGeneratedBy) > 0 {
+		problems = append(problems, "skip-generated-by has no effect with skip-any-generated")
+	}
+
+	for _, list := range []struct {
+		name   string
+		values []string
+	}{
+		{"skip-generated-by", cfg.SkipGeneratedBy},
+		{"skip-files", cfg.SkipFiles},
+	} {
+		seen := make(map[string]bool)
+		for _, v := range list.values {
+			if seen[v] {
+				problems = append(problems, fmt.Sprintf("%s: duplicated value %q", list.name, v))
+			}
+			seen[v] = true
+		}
+	}
+
+	return problems
+}
+
+// effectiveConfig is the merged configuration printed by config check.
+type effectiveConfig struct {
+	SkipGeneratedBy     []string `yaml:"skip-generated-by,omitempty"`
+	SkipFiles           []string `yaml:"skip-files,omitempty"`
+	SkipAnyGenerated    bool     `yaml:"skip-any-generated"`
+	SkipNonNilReceivers bool     `yaml:"skip-non-nil-receivers"`
+	Debug               bool     `yaml:"debug"`
+}
+
+func printConfig(w io.Writer, cfg *protogetter.Config) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(effectiveConfig{
+		SkipGeneratedBy:     cfg.SkipGeneratedBy,
+		SkipFiles:           cfg.SkipFiles,
+		SkipAnyGenerated:    cfg.SkipAnyGenerated,
+		SkipNonNilReceivers: cfg.SkipNonNilReceivers,
+		Debug:               cfg.Debug,
+	}); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// configCheck runs the config check subcommand: it validates the config file merged with the flags
+// and prints the effective configuration.
+func configCheck(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: protogetter config check [-config=file] [-flag]")
+		return exitError
+	}
+
+	cfg := &protogetter.Config{}
+	analyzer := protogetter.NewAnalyzer(cfg)
+
+	fs := flag.NewFlagSet("config check", flag.ContinueOnError)
+	configFlag := fs.String("config", "", "config file (default: "+defaultConfigFile+" in the current directory)")
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitError
+	}
+
+	filename := configFile(*configFlag)
+	if filename == "" {
+		fmt.Fprintf(os.Stderr, "config: %s not found\n", defaultConfigFile)
+		return exitError
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	problems, err := loadConfig(filename, &analyzer.Flags, set)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return exitError
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].line < problems[j].line })
+
+	ok := true
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s\n", filename, p.line, p.message)
+		ok = false
+	}
+	for _, p := range checkConfig(cfg) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", filename, p)
+		ok = false
+	}
+
+	if err := printConfig(os.Stdout, cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+		return exitError
+	}
+
+	if !ok {
+		return exitError
+	}
+
+	return exitOK
+}
+
+// applyConfig loads the config file into the analyzer flags, which were not set on the command line.
+func applyConfig(filename string, fs, analyzerFlags *flag.FlagSet) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	problems, err := loadConfig(filename, analyzerFlags, set)
+	if err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		p := problems[0]
+		return fmt.Errorf("%s:%d: %s (run protogetter config check for details)", filename, p.line, p.message)
+	}
+
+	return nil
+}
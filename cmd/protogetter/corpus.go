@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// corpusConfig is the list of repositories used to catch false positive and false negative regressions.
+type corpusConfig struct {
+	Repos []corpusRepo `yaml:"repos"`
+}
+
+type corpusRepo struct {
+	// Name identifies the repository in the output and names its checkout directory.
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Ref is a tag or a branch to check out, pin tags to get reproducible results.
+	Ref string `yaml:"ref"`
+	// Dir is the directory of the Go module inside the repository.
+	Dir      string   `yaml:"dir"`
+	Packages []string `yaml:"packages"`
+	// Flags are the analyzer flags, for example "-skip-any-generated".
+	Flags []string `yaml:"flags"`
+	// Expected is the file with the recorded issue fingerprints, relative to the corpus file.
+	Expected string `yaml:"expected"`
+}
+
+// corpus runs the corpus subcommands.
+func corpus(args []string) int {
+	if len(args) == 0 || args[0] != "run" {
+		fmt.Fprintln(os.Stderr, "Usage: protogetter corpus run [-update] [-dir=checkouts] corpus.yaml")
+		return exitError
+	}
+
+	fs := flag.NewFlagSet("corpus run", flag.ContinueOnError)
+	update := fs.Bool("update", false, "record the current issues as expected instead of comparing with them")
+	dir := fs.String("dir", "", "directory for repository checkouts (default: user cache directory)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitError
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "corpus: expected a single corpus file")
+		return exitError
+	}
+
+	ok, err := runCorpus(fs.Arg(0), *dir, *update)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "corpus: %v\n", err)
+		return exitError
+	}
+
+	if !ok {
+		return exitIssues
+	}
+
+	return exitOK
+}
+
+// runCorpus analyzes the repositories of the corpus and reports differences between the found and the expected
+// issues. It returns false if there are any.
+func runCorpus(filename, checkoutDir string, update bool) (bool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	var cfg corpusConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return false, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if checkoutDir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return false, err
+		}
+		checkoutDir = filepath.Join(cacheDir, "protogetter", "corpus")
+	}
+
+	ok := true
+	for _, repo := range cfg.Repos {
+		if repo.Name == "" || repo.URL == "" || repo.Expected == "" {
+			return false, fmt.Errorf("%s: name, url and expected are required for each repository", filename)
+		}
+
+		repoDir := filepath.Join(checkoutDir, repo.Name)
+		if err := checkout(repo, repoDir); err != nil {
+			return false, fmt.Errorf("%s: %w", repo.Name, err)
+		}
+
+		found, err := corpusIssues(repo, filepath.Join(repoDir, repo.Dir))
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", repo.Name, err)
+		}
+
+		expectedFile := filepath.Join(filepath.Dir(filename), repo.Expected)
+		if update {
+			if err := writeExpected(expectedFile, found); err != nil {
+				return false, err
+			}
+			fmt.Printf("%s: recorded %s\n", repo.Name, plural(len(found), "issue"))
+			continue
+		}
+
+		expected, err := readExpected(expectedFile)
+		if err != nil {
+			return false, err
+		}
+
+		if !diffCorpus(repo.Name, expected, found) {
+			ok = false
+		}
+	}
+
+	return ok, nil
+}
+
+// checkout clones the repository at the ref, or updates the existing checkout.
+func checkout(repo corpusRepo, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		args := []string{"clone", "-q", "--depth=1"}
+		if repo.Ref != "" {
+			args = append(args, "--branch="+repo.Ref)
+		}
+		return git(append(args, repo.URL, dir)...)
+	}
+
+	ref := repo.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if err := git("-C", dir, "fetch", "-q", "--depth=1", repo.URL, ref); err != nil {
+		return err
+	}
+
+	return git("-C", dir, "checkout", "-q", "--force", "FETCH_HEAD")
+}
+
+func git(args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+
+	return nil
+}
+
+// corpusIssues analyzes the packages of the repository and returns the found issues by fingerprint.
+func corpusIssues(repo corpusRepo, dir string) (map[string]string, error) {
+	cfg := &protogetter.Config{}
+	analyzer := protogetter.NewAnalyzer(cfg)
+	if err := analyzer.Flags.Parse(repo.Flags); err != nil {
+		return nil, err
+	}
+
+	patterns := repo.Packages
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	d := newDriver(analyzer, &options{Tests: true, Parallel: 1})
+	d.dir = dir
+
+	issues, err := d.loadAndAnalyze(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]string, len(issues))
+	for _, is := range issues {
+		rel, err := filepath.Rel(dir, is.pos.Filename)
+		if err != nil {
+			rel = is.pos.Filename
+		}
+		rel = filepath.ToSlash(rel)
+
+		found[corpusFingerprint(rel, is)] = fmt.Sprintf("%s:%d: %s", rel, is.pos.Line, is.diag.Message)
+	}
+
+	return found, nil
+}
+
+// corpusFingerprint identifies the issue by its file, message and the text of the offending line,
+// so that it does not change when unrelated lines are added or removed.
+func corpusFingerprint(rel string, is issue) string {
+	line := ""
+	if data, err := os.ReadFile(is.pos.Filename); err == nil {
+		if lines := splitLines(data); is.pos.Line <= len(lines) {
+			line = strings.TrimSpace(lines[is.pos.Line-1])
+		}
+	}
+
+	h := sha256.Sum256([]byte(rel + "\x00" + is.diag.Message + "\x00" + line))
+	return hex.EncodeToString(h[:8])
+}
+
+// The expected file contains a line per issue: the fingerprint followed by a human-readable description.
+func readExpected(filename string) (map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	expected := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fingerprint, desc, _ := strings.Cut(line, " ")
+		expected[fingerprint] = desc
+	}
+
+	return expected, sc.Err()
+}
+
+func writeExpected(filename string, found map[string]string) error {
+	var b strings.Builder
+	b.WriteString("# Generated by protogetter corpus run -update.\n")
+	for _, fingerprint := range sortedByDesc(found) {
+		fmt.Fprintf(&b, "%s %s\n", fingerprint, found[fingerprint])
+	}
+
+	return os.WriteFile(filename, []byte(b.String()), 0o644)
+}
+
+// diffCorpus prints new issues (possible false positives) and missing issues (possible false negatives),
+// and returns true if there are none.
+func diffCorpus(name string, expected, found map[string]string) bool {
+	var added, removed []string
+	for _, fingerprint := range sortedByDesc(found) {
+		if _, ok := expected[fingerprint]; !ok {
+			added = append(added, found[fingerprint])
+		}
+	}
+	for _, fingerprint := range sortedByDesc(expected) {
+		if _, ok := found[fingerprint]; !ok {
+			removed = append(removed, expected[fingerprint])
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Printf("%s: ok, %s\n", name, plural(len(found), "issue"))
+		return true
+	}
+
+	fmt.Printf("%s: %d new, %d missing\n", name, len(added), len(removed))
+	for _, desc := range added {
+		fmt.Printf("  + %s\n", desc)
+	}
+	for _, desc := range removed {
+		fmt.Printf("  - %s\n", desc)
+	}
+
+	return false
+}
+
+func sortedByDesc(issues map[string]string) []string {
+	fingerprints := make([]string, 0, len(issues))
+	for fingerprint := range issues {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	sort.Slice(fingerprints, func(i, j int) bool {
+		a, b := issues[fingerprints[i]], issues[fingerprints[j]]
+		if a != b {
+			return a < b
+		}
+		return fingerprints[i] < fingerprints[j]
+	})
+
+	return fingerprints
+}
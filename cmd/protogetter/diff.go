@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// changedLines contains the lines added or modified by a diff by absolute filename.
+// Issues on other lines are not reported, so the rules can be enforced only for new and modified code.
+type changedLines map[string]map[int]bool
+
+// loadChanges returns the lines changed by the patch file or since the git revision,
+// nil if neither is set.
+func loadChanges(dir, patchFile, rev string) (changedLines, error) {
+	if patchFile != "" && rev != "" {
+		return nil, errors.New("-new-from-patch and -new-from-rev can not be used together")
+	}
+
+	if patchFile == "" && rev == "" {
+		return nil, nil
+	}
+
+	// Paths in git diffs are relative to the root of the repository.
+	root, err := gitRoot(dir)
+	if err != nil {
+		if rev != "" {
+			return nil, err
+		}
+
+		if root, err = filepath.Abs(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	if rev != "" {
+		cmd := exec.Command("git", "diff", "--no-color", "--no-ext-diff", "-U0", rev, "--")
+		cmd.Dir = root
+		cmd.Stderr = os.Stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("git diff %s: %w", rev, err)
+		}
+
+		return parseUnifiedDiff(bytes.NewReader(out), root)
+	}
+
+	f, err := os.Open(patchFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseUnifiedDiff(f, root)
+}
+
+func gitRoot(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --show-toplevel: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// parseUnifiedDiff returns the added lines of the new files of the unified diff,
+// the paths are resolved relative to root.
+func parseUnifiedDiff(r io.Reader, root string) (changedLines, error) {
+	changes := make(changedLines)
+
+	var (
+		lines map[int]bool
+		line  int
+	)
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		text := sc.Text()
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			name := strings.TrimPrefix(text, "+++ ")
+			if i := strings.IndexByte(name, '\t'); i >= 0 {
+				name = name[:i]
+			}
+
+			if name == "/dev/null" {
+				lines = nil
+				continue
+			}
+
+			name = strings.TrimPrefix(name, "b/")
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(root, filepath.FromSlash(name))
+			}
+
+			lines = changes[name]
+			if lines == nil {
+				lines = make(map[int]bool)
+				changes[name] = lines
+			}
+
+		case strings.HasPrefix(text, "@@ "):
+			start, err := hunkStart(text)
+			if err != nil {
+				return nil, err
+			}
+			line = start
+
+		case lines == nil || strings.HasPrefix(text, "--- ") || strings.HasPrefix(text, `\`):
+			continue
+
+		case strings.HasPrefix(text, "+"):
+			lines[line] = true
+			line++
+
+		case strings.HasPrefix(text, " "):
+			line++
+		}
+	}
+
+	return changes, sc.Err()
+}
+
+// hunkStart returns the first line of the new file in the hunk header like "@@ -1,2 +3,4 @@".
+func hunkStart(header string) (int, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0, fmt.Errorf("invalid hunk header %q", header)
+	}
+
+	start, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+	n, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hunk header %q", header)
+	}
+
+	return n, nil
+}
+
+// filter returns the issues which span at least one changed line, all issues if changes is nil.
+func (c changedLines) filter(issues []issue) []issue {
+	if c == nil {
+		return issues
+	}
+
+	var changed []issue
+	for _, is := range issues {
+		lines := c[is.pos.Filename]
+		for line := is.pos.Line; line <= max(is.end.Line, is.pos.Line); line++ {
+			if lines[line] {
+				changed = append(changed, is)
+				break
+			}
+		}
+	}
+
+	return changed
+}
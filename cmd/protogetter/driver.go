@@ -0,0 +1,396 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// driver loads packages, runs the analyzer on them and prints the results.
+type driver struct {
+	analyzer *analysis.Analyzer
+	opts     *options
+	out      io.Writer
+
+	// dir is the directory in which patterns are resolved, the current directory if empty.
+	dir string
+	// overlay contains contents of files which differ from the files on disk.
+	overlay map[string][]byte
+
+	// loadErrors is the number of errors during loading, the run fails if there are no issues.
+	loadErrors atomic.Int64
+
+	// metrics is nil unless -metrics is set.
+	metrics *metrics
+
+	// changes is nil unless -new-from-patch or -new-from-rev is set.
+	changes changedLines
+
+	// paths is nil unless -path-mode or -path-prefix-trim rewrite the printed paths.
+	paths *pathFormat
+
+	// variant is the build configuration in which packages are loaded, the default one if empty.
+	variant buildVariant
+}
+
+func newDriver(analyzer *analysis.Analyzer, opts *options) *driver {
+	return &driver{
+		analyzer: analyzer,
+		opts:     opts,
+		out:      os.Stdout,
+	}
+}
+
+// issue is a diagnostic of the root package. It does not retain the package itself,
+// so syntax and type information may be freed as soon as the package is analyzed.
+type issue struct {
+	pkgID string
+	fset  *token.FileSet
+	diag  analysis.Diagnostic
+	pos   token.Position
+	end   token.Position
+}
+
+func (d *driver) run(patterns []string) (int, error) {
+	var issues []issue
+	err := d.forEachVariant(func() error {
+		variantIssues, err := d.loadAndAnalyze(patterns)
+		issues = append(issues, variantIssues...)
+		return err
+	})
+	if err != nil {
+		return exitError, err
+	}
+
+	return d.report(sortIssues(issues))
+}
+
+// report applies fixes of the issues if requested, prints them and returns the exit code.
+func (d *driver) report(issues []issue) (int, error) {
+	issues = d.changes.filter(issues)
+	d.metrics.setIssues(issues)
+
+	verified := true
+	if d.opts.Fix {
+		fixes := issues
+		if d.opts.Interactive {
+			var err error
+			fixes, err = selectFixes(d.out, os.Stdin, issues, d.opts.Context)
+			if err != nil {
+				return exitError, err
+			}
+		}
+
+		start := time.Now()
+		modified, err := applyFixes(fixes)
+		d.metrics.phase("fix", start)
+		if err != nil {
+			return exitError, err
+		}
+
+		if d.opts.Verify && len(modified) > 0 {
+			verified, err = d.verifyFixes(modified)
+			if err != nil {
+				return exitError, err
+			}
+		}
+	}
+
+	printed := issues
+	if d.opts.FailOnlyFixable && !d.opts.JSON {
+		printed = markWarnings(issues)
+	}
+
+	if err := d.print(printed); err != nil {
+		return exitError, err
+	}
+
+	if !verified || (len(issues) == 0 && d.loadErrors.Load() > 0) {
+		return exitError, nil
+	}
+
+	if d.opts.FailOnlyFixable {
+		issues = slices.DeleteFunc(slices.Clone(issues), func(is issue) bool {
+			return len(is.diag.SuggestedFixes) == 0
+		})
+	}
+
+	if (d.opts.JSON && !d.opts.Quiet) || len(issues) == 0 {
+		return exitOK, nil
+	}
+
+	return exitIssues, nil
+}
+
+// markWarnings returns a copy of the issues with the messages of issues without fixes prefixed with "warning: ",
+// since they do not fail the run with -fail-only-fixable.
+func markWarnings(issues []issue) []issue {
+	marked := slices.Clone(issues)
+	for i := range marked {
+		if len(marked[i].diag.SuggestedFixes) == 0 {
+			marked[i].diag.Message = "warning: " + marked[i].diag.Message
+		}
+	}
+
+	return marked
+}
+
+func (d *driver) load(patterns []string) ([]*packages.Package, error) {
+	defer d.metrics.phase("load", time.Now())
+
+	cfg := &packages.Config{
+		Mode:       packages.LoadAllSyntax,
+		Dir:        d.dir,
+		Env:        d.variant.environ(),
+		BuildFlags: d.variant.buildFlags,
+		Tests:      d.opts.Tests,
+		Overlay:    d.overlay,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Errors are reported, but do not abort the run, see bestEffort.
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		d.loadErrors.Add(int64(n))
+	}
+
+	return pkgs, nil
+}
+
+// analyze runs the analyzer on the packages and returns diagnostics of the root packages,
+// deduplicated (files may belong to several packages, such as foo and foo.test) and sorted by position.
+func (d *driver) analyze(pkgs []*packages.Package) ([]issue, error) {
+	defer d.metrics.phase("analyze", time.Now())
+
+	analyzer, errorFiles := d.bestEffort(pkgs)
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []issue
+	for _, act := range graph.Roots {
+		d.metrics.addPackage(act.Package.ID)
+
+		if act.Err != nil {
+			if len(act.Package.Errors) > 0 {
+				fmt.Fprintf(os.Stderr, "%s: %s: skipped due to errors: %v\n", d.analyzer.Name, act.Package.PkgPath, act.Err)
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err)
+		}
+
+		for _, diag := range act.Diagnostics {
+			issues = append(issues, issue{
+				pkgID: act.Package.ID,
+				fset:  act.Package.Fset,
+				diag:  diag,
+				pos:   act.Package.Fset.Position(diag.Pos),
+				end:   act.Package.Fset.Position(diag.End),
+			})
+		}
+	}
+
+	return sortIssues(d.skipErrorFiles(issues, errorFiles)), nil
+}
+
+// sortIssues removes duplicated issues and sorts them by position.
+func sortIssues(issues []issue) []issue {
+	type key struct {
+		pos     token.Position
+		end     token.Position
+		message string
+	}
+	seen := make(map[key]struct{})
+
+	unique := issues[:0]
+	for _, is := range issues {
+		k := key{is.pos, is.end, is.diag.Message}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		unique = append(unique, is)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		a, b := unique[i].pos, unique[j].pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+
+	return unique
+}
+
+func (d *driver) print(issues []issue) error {
+	defer d.metrics.phase("print", time.Now())
+
+	if d.opts.Quiet {
+		if !d.opts.Summary {
+			return nil
+		}
+		return printSummary(d.out, issues)
+	}
+
+	if d.opts.JSON {
+		return printJSON(d.out, d.analyzer.Name, issues, d.paths)
+	}
+
+	switch d.opts.Format {
+	case formatGrouped:
+		return printGrouped(d.out, issues, d.opts.Context, d.paths)
+	default:
+		return printText(d.out, issues, d.opts.Context, d.paths)
+	}
+}
+
+func printText(w io.Writer, issues []issue, contextLines int, paths *pathFormat) error {
+	for _, is := range issues {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", paths.position(is.pos), is.diag.Message); err != nil {
+			return err
+		}
+
+		if contextLines < 0 {
+			continue
+		}
+
+		if err := printContext(w, is, contextLines); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printContext prints the offending lines with contextLines lines before and after them.
+func printContext(w io.Writer, is issue, contextLines int) error {
+	data, err := os.ReadFile(is.pos.Filename)
+	if err != nil {
+		return err
+	}
+
+	lines := splitLines(data)
+
+	end := is.end.Line
+	if end < is.pos.Line {
+		end = is.pos.Line
+	}
+
+	for i := max(is.pos.Line-contextLines, 1); i <= min(end+contextLines, len(lines)); i++ {
+		if _, err := fmt.Fprintf(w, "%d\t%s\n", i, lines[i-1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func splitLines(data []byte) []string {
+	var lines []string
+	start := 0
+	for i, b := range data {
+		if b == '\n' {
+			lines = append(lines, string(data[start:i]))
+			start = i + 1
+		}
+	}
+	if start < len(data) {
+		lines = append(lines, string(data[start:]))
+	}
+
+	return lines
+}
+
+// The JSON output is compatible with the output of go vet -json:
+// map[package ID]map[analyzer name][]diagnostic.
+type jsonDiagnostic struct {
+	Category       string              `json:"category,omitempty"`
+	Posn           string              `json:"posn"`
+	End            string              `json:"end,omitempty"`
+	Message        string              `json:"message"`
+	SuggestedFixes []jsonSuggestedFix  `json:"suggested_fixes,omitempty"`
+	Related        []jsonRelatedInform `json:"related,omitempty"`
+}
+
+type jsonSuggestedFix struct {
+	Message string         `json:"message"`
+	Edits   []jsonTextEdit `json:"edits"`
+}
+
+type jsonTextEdit struct {
+	Filename string `json:"filename"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	New      string `json:"new"`
+}
+
+type jsonRelatedInform struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+func printJSON(w io.Writer, analyzerName string, issues []issue, paths *pathFormat) error {
+	tree := make(map[string]map[string][]jsonDiagnostic)
+	for _, is := range issues {
+		fset := is.fset
+
+		diag := jsonDiagnostic{
+			Category: is.diag.Category,
+			Posn:     paths.position(is.pos),
+			Message:  is.diag.Message,
+		}
+		if is.diag.End.IsValid() {
+			diag.End = paths.position(is.end)
+		}
+
+		for _, fix := range is.diag.SuggestedFixes {
+			jsonFix := jsonSuggestedFix{Message: fix.Message}
+			for _, edit := range fix.TextEdits {
+				jsonFix.Edits = append(jsonFix.Edits, jsonTextEdit{
+					Filename: paths.path(fset.Position(edit.Pos).Filename),
+					Start:    fset.Position(edit.Pos).Offset,
+					End:      fset.Position(edit.End).Offset,
+					New:      string(edit.NewText),
+				})
+			}
+			diag.SuggestedFixes = append(diag.SuggestedFixes, jsonFix)
+		}
+
+		for _, rel := range is.diag.Related {
+			diag.Related = append(diag.Related, jsonRelatedInform{
+				Posn:    paths.position(fset.Position(rel.Pos)),
+				Message: rel.Message,
+			})
+		}
+
+		if tree[is.pkgID] == nil {
+			tree[is.pkgID] = make(map[string][]jsonDiagnostic)
+		}
+		tree[is.pkgID][analyzerName] = append(tree[is.pkgID][analyzerName], diag)
+	}
+
+	data, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// explain prints the rationale and examples of the given rules, or the list of all rules.
+func explain(args []string) int {
+	if len(args) == 0 {
+		fmt.Println("Usage: protogetter explain RULE...")
+		fmt.Println()
+		fmt.Println("Rules:")
+		for _, r := range protogetter.Rules() {
+			fmt.Printf("  %s  %-15s %s\n", r.ID, r.Analyzer, r.Summary)
+		}
+		return 0
+	}
+
+	for i, id := range args {
+		doc, err := protogetter.ExplainRule(strings.ToUpper(id))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(doc)
+	}
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runFiles analyzes only the packages containing the given files and reports issues of these files,
+// which is useful for pre-commit hooks in large repositories. Without arguments, the list of files
+// is read from r, separated by NUL (as printed by git diff -z) or newlines.
+func (d *driver) runFiles(args []string, r io.Reader) (int, error) {
+	if len(args) == 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return exitError, err
+		}
+		args = splitFileList(data)
+	}
+
+	files := make(map[string]bool)
+	for _, arg := range args {
+		// Hooks pass all changed files, including non-Go and deleted ones.
+		if filepath.Ext(arg) != ".go" {
+			continue
+		}
+
+		filename, err := filepath.Abs(arg)
+		if err != nil {
+			return exitError, err
+		}
+
+		if _, err := os.Stat(filename); err != nil {
+			continue
+		}
+
+		files[filename] = true
+	}
+
+	if len(files) == 0 {
+		return exitOK, nil
+	}
+
+	patterns := filePatterns(files)
+
+	var issues []issue
+	err := d.forEachVariant(func() error {
+		pkgs, err := d.load(patterns)
+		if err != nil {
+			return err
+		}
+
+		variantIssues, err := d.analyze(pkgs)
+		issues = append(issues, variantIssues...)
+		return err
+	})
+	if err != nil {
+		return exitError, err
+	}
+
+	var fileIssues []issue
+	for _, is := range issues {
+		if files[is.pos.Filename] {
+			fileIssues = append(fileIssues, is)
+		}
+	}
+
+	return d.report(sortIssues(fileIssues))
+}
+
+func splitFileList(data []byte) []string {
+	sep := []byte{0}
+	if !bytes.Contains(data, sep) {
+		sep = []byte{'\n'}
+	}
+
+	var list []string
+	for _, name := range bytes.Split(data, sep) {
+		name := strings.TrimSpace(string(name))
+		if name != "" {
+			list = append(list, name)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+)
+
+type fileEdit struct {
+	start, end int
+	newText    []byte
+}
+
+// applyFixes applies the first suggested fix of each issue and returns the modified files.
+// Identical edits are applied once, edits overlapping with already accepted ones are skipped.
+func applyFixes(issues []issue) ([]string, error) {
+	editsByFile := make(map[string][]fileEdit)
+	for _, is := range issues {
+		if len(is.diag.SuggestedFixes) == 0 {
+			continue
+		}
+
+		for filename, edits := range fixEdits(is) {
+			editsByFile[filename] = append(editsByFile[filename], edits...)
+		}
+	}
+
+	filenames := make([]string, 0, len(editsByFile))
+	for filename := range editsByFile {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		out, skipped := applyEdits(src, editsByFile[filename])
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %d conflicting edits skipped\n", filename, skipped)
+		}
+
+		// Realign comments around the fixed lines, but do not reformat files which were not formatted before.
+		if formatted, err := format.Source(src); err == nil && bytes.Equal(formatted, src) {
+			if formatted, err := format.Source(out); err == nil {
+				out = formatted
+			}
+		}
+
+		if err := os.WriteFile(filename, out, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	return filenames, nil
+}
+
+// fixEdits returns edits of the first suggested fix of the issue by filename.
+func fixEdits(is issue) map[string][]fileEdit {
+	editsByFile := make(map[string][]fileEdit)
+	for _, edit := range is.diag.SuggestedFixes[0].TextEdits {
+		file := is.fset.File(edit.Pos)
+		if file == nil {
+			continue
+		}
+
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+
+		editsByFile[file.Name()] = append(editsByFile[file.Name()], fileEdit{
+			start:   file.Offset(edit.Pos),
+			end:     file.Offset(end),
+			newText: edit.NewText,
+		})
+	}
+
+	return editsByFile
+}
+
+// applyEdits applies non-overlapping edits to the source and returns the number of skipped conflicting edits.
+func applyEdits(src []byte, edits []fileEdit) ([]byte, int) {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].start != edits[j].start {
+			return edits[i].start < edits[j].start
+		}
+		return edits[i].end < edits[j].end
+	})
+
+	var (
+		out     bytes.Buffer
+		last    = 0
+		prev    *fileEdit
+		skipped int
+	)
+	for i := range edits {
+		edit := &edits[i]
+
+		if prev != nil && edit.start == prev.start && edit.end == prev.end && bytes.Equal(edit.newText, prev.newText) {
+			// Duplicate edit.
+			continue
+		}
+
+		if edit.start < last {
+			skipped++
+			continue
+		}
+
+		out.Write(src[last:edit.start])
+		out.Write(edit.newText)
+		last = edit.end
+		prev = edit
+	}
+	out.Write(src[last:])
+
+	return out.Bytes(), skipped
+}
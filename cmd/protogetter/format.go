@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Output formats of the -format flag.
+const (
+	formatText    = "text"
+	formatGrouped = "grouped"
+)
+
+var formats = []string{formatText, formatGrouped}
+
+// printGrouped prints issues under headers of their files with per-file counts, followed by a summary,
+// which is easier to scan than interleaved lines when there are many issues.
+func printGrouped(w io.Writer, issues []issue, contextLines int, paths *pathFormat) error {
+	files := 0
+	for i := 0; i < len(issues); {
+		filename := issues[i].pos.Filename
+
+		j := i
+		for j < len(issues) && issues[j].pos.Filename == filename {
+			j++
+		}
+
+		if files > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		files++
+
+		if _, err := fmt.Fprintf(w, "%s (%s)\n", paths.path(filename), plural(j-i, "issue")); err != nil {
+			return err
+		}
+
+		for _, is := range issues[i:j] {
+			if _, err := fmt.Fprintf(w, "  %d:%d\t%s\n", is.pos.Line, is.pos.Column, is.diag.Message); err != nil {
+				return err
+			}
+
+			if contextLines < 0 {
+				continue
+			}
+
+			if err := printContext(w, is, contextLines); err != nil {
+				return err
+			}
+		}
+
+		i = j
+	}
+
+	if files > 0 {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return printSummary(w, issues)
+}
+
+// printSummary prints the number of issues and files containing them in one line.
+func printSummary(w io.Writer, issues []issue) error {
+	files := 0
+	for i, is := range issues {
+		if i == 0 || is.pos.Filename != issues[i-1].pos.Filename {
+			files++
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Found %s in %s.\n", plural(len(issues), "issue"), plural(files, "file"))
+	return err
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const interactiveHelp = `y - apply this fix
+n - skip this fix
+a - apply this fix and all remaining fixes
+q - skip this fix and all remaining fixes
+? - print help
+`
+
+// selectFixes shows each fixable issue with the proposed rewrite and asks whether to apply it,
+// similar to git add -p. It returns the issues whose fixes are accepted.
+func selectFixes(w io.Writer, r io.Reader, issues []issue, contextLines int) ([]issue, error) {
+	in := bufio.NewReader(r)
+	sources := make(map[string][]byte)
+
+	var accepted []issue
+	for i, is := range issues {
+		if len(is.diag.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if err := printFixPreview(w, is, sources, max(contextLines, 0)); err != nil {
+			return nil, err
+		}
+
+	prompt:
+		fmt.Fprintf(w, "Apply this fix [y,n,a,q,?]? ")
+
+		answer, err := in.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			// No more answers, skip the remaining fixes.
+			fmt.Fprintln(w)
+			return accepted, nil
+		}
+
+		switch strings.TrimSpace(answer) {
+		case "y":
+			accepted = append(accepted, is)
+		case "n":
+		case "a":
+			return append(accepted, issues[i:]...), nil
+		case "q":
+			return accepted, nil
+		default:
+			fmt.Fprint(w, interactiveHelp)
+			goto prompt
+		}
+	}
+
+	return accepted, nil
+}
+
+// printFixPreview prints the issue and the lines affected by its fix before and after the fix,
+// with contextLines unchanged lines around them.
+func printFixPreview(w io.Writer, is issue, sources map[string][]byte, contextLines int) error {
+	if _, err := fmt.Fprintf(w, "\n%s: %s\n", is.pos, is.diag.Message); err != nil {
+		return err
+	}
+
+	editsByFile := fixEdits(is)
+
+	filenames := make([]string, 0, len(editsByFile))
+	for filename := range editsByFile {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		src, ok := sources[filename]
+		if !ok {
+			var err error
+			src, err = os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			sources[filename] = src
+		}
+
+		edits := editsByFile[filename]
+		out, _ := applyEdits(src, edits)
+
+		// Edits are sorted by applyEdits, find the affected lines.
+		start := bytes.LastIndexByte(src[:edits[0].start], '\n') + 1
+		end := edits[len(edits)-1].end
+		if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+			end += i
+		} else {
+			end = len(src)
+		}
+		// The tail of the file is the same after the fix.
+		newEnd := end + len(out) - len(src)
+
+		before := splitLines(src[:start])
+		after := splitLines(src[end:])
+		if len(after) > 0 {
+			// Skip the rest of the last changed line.
+			after = after[1:]
+		}
+
+		for _, line := range before[max(len(before)-contextLines, 0):] {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+		for _, line := range splitLines(src[start:end]) {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+		for _, line := range splitLines(out[start:newEnd]) {
+			fmt.Fprintf(w, "+ %s\n", line)
+		}
+		for _, line := range after[:min(contextLines, len(after))] {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+// list prints the packages and/or files which would be analyzed, after skipping generated files and files
+// matched by glob patterns, without running the checks.
+func list(w io.Writer, cfg *protogetter.Config, opts *options, patterns []string) error {
+	loadCfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+		Tests: opts.Tests,
+	}
+
+	pkgs, err := packages.Load(loadCfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return fmt.Errorf("%d errors during loading", n)
+	}
+
+	var (
+		pkgIDs    []string
+		filenames []string
+		seen      = make(map[string]bool)
+	)
+
+	for _, pkg := range pkgs {
+		// Skip generated test main packages.
+		if strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+
+		files, err := protogetter.FilterFiles(pkg.Fset, pkg.Syntax, cfg)
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		pkgIDs = append(pkgIDs, pkg.ID)
+
+		for _, f := range files {
+			// Files of a package are also files of its test variant.
+			filename := pkg.Fset.File(f.Pos()).Name()
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+			filenames = append(filenames, filename)
+		}
+	}
+
+	if opts.ListPackages {
+		for _, id := range pkgIDs {
+			fmt.Fprintln(w, id)
+		}
+	}
+
+	if opts.ListFiles {
+		for _, filename := range filenames {
+			fmt.Fprintln(w, filename)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,552 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lspDebounce is the time to wait for more changes of a document before re-analyzing it.
+const lspDebounce = 300 * time.Millisecond
+
+// runLSP runs a Language Server Protocol server over stdio, which publishes diagnostics of open documents
+// and provides quick fixes as code actions.
+func runLSP(analyzer *analysis.Analyzer, opts *options) int {
+	s := newLSPServer(newDriver(analyzer, opts), os.Stdin, os.Stdout)
+	if err := s.serve(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: lsp: %v\n", analyzer.Name, err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+type lspServer struct {
+	driver *driver
+	in     *bufio.Reader
+	out    io.Writer
+
+	writeMu sync.Mutex
+
+	mu          sync.Mutex
+	docs        map[string][]byte          // filename -> contents of open documents
+	diagnostics map[string][]lspDiagnostic // filename -> last published diagnostics
+	timers      map[string]*time.Timer
+	analyzeMu   sync.Mutex
+	shutdown    bool
+}
+
+func newLSPServer(d *driver, in io.Reader, out io.Writer) *lspServer {
+	return &lspServer{
+		driver:      d,
+		in:          bufio.NewReader(in),
+		out:         out,
+		docs:        make(map[string][]byte),
+		diagnostics: make(map[string][]lspDiagnostic),
+		timers:      make(map[string]*time.Timer),
+	}
+}
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  any              `json:"result,omitempty"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspTextEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+
+	RelatedInformation []lspRelatedInformation `json:"relatedInformation,omitempty"`
+
+	// fixes are quick fixes of the diagnostic, by file URI.
+	fixes []lspFix
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspRelatedInformation struct {
+	Location lspLocation `json:"location"`
+	Message  string      `json:"message"`
+}
+
+type lspFix struct {
+	title string
+	edits map[string][]lspTextEdit
+}
+
+type lspTextDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDidOpenParams struct {
+	TextDocument lspTextDocumentItem `json:"textDocument"`
+}
+
+type lspDidChangeParams struct {
+	TextDocument   lspTextDocumentItem `json:"textDocument"`
+	ContentChanges []struct {
+		Range *lspRange `json:"range,omitempty"`
+		Text  string    `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspDocumentParams struct {
+	TextDocument lspTextDocumentItem `json:"textDocument"`
+}
+
+type lspCodeActionParams struct {
+	TextDocument lspTextDocumentItem `json:"textDocument"`
+	Range        lspRange            `json:"range"`
+}
+
+const (
+	lspSeverityWarning     = 2
+	lspSeverityInformation = 3
+	lspSyncFull            = 1
+	lspErrMethodNotFound   = -32601
+	lspErrInvalidRequest   = -32600
+)
+
+func (s *lspServer) serve() error {
+	for {
+		msg, err := s.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		s.handle(msg)
+	}
+}
+
+func (s *lspServer) handle(msg *lspMessage) {
+	var (
+		result any
+		err    *lspError
+	)
+
+	switch msg.Method {
+	case "initialize":
+		result = map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					"change":    lspSyncFull,
+					"save":      true,
+				},
+				"codeActionProvider": true,
+			},
+			"serverInfo": map[string]any{
+				"name": s.driver.analyzer.Name,
+			},
+		}
+
+	case "initialized", "$/cancelRequest", "workspace/didChangeConfiguration":
+
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+
+	case "textDocument/didOpen":
+		var params lspDidOpenParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.setDocument(params.TextDocument.URI, []byte(params.TextDocument.Text))
+			s.scheduleAnalysis(params.TextDocument.URI, 0)
+		}
+
+	case "textDocument/didChange":
+		var params lspDidChangeParams
+		if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			// Only full document sync is supported, so the last change contains the whole document.
+			s.setDocument(params.TextDocument.URI, []byte(params.ContentChanges[len(params.ContentChanges)-1].Text))
+			s.scheduleAnalysis(params.TextDocument.URI, lspDebounce)
+		}
+
+	case "textDocument/didSave":
+		var params lspDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.scheduleAnalysis(params.TextDocument.URI, 0)
+		}
+
+	case "textDocument/didClose":
+		var params lspDocumentParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.closeDocument(params.TextDocument.URI)
+		}
+
+	case "textDocument/codeAction":
+		var params lspCodeActionParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			result = s.codeActions(params)
+		} else {
+			err = &lspError{Code: lspErrInvalidRequest, Message: "invalid params"}
+		}
+
+	default:
+		if msg.ID != nil {
+			err = &lspError{Code: lspErrMethodNotFound, Message: "method not found: " + msg.Method}
+		}
+	}
+
+	if msg.ID == nil {
+		// Notification.
+		return
+	}
+
+	resp := &lspMessage{JSONRPC: "2.0", ID: msg.ID, Error: err}
+	if err == nil {
+		if result == nil {
+			result = json.RawMessage("null")
+		}
+		resp.Result = result
+	}
+
+	s.write(resp)
+}
+
+func (s *lspServer) setDocument(uri string, text []byte) {
+	filename, ok := uriToFilename(uri)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.docs[filename] = text
+	s.mu.Unlock()
+}
+
+func (s *lspServer) closeDocument(uri string) {
+	filename, ok := uriToFilename(uri)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.docs, filename)
+	delete(s.diagnostics, filename)
+	if t, ok := s.timers[filename]; ok {
+		t.Stop()
+		delete(s.timers, filename)
+	}
+	s.mu.Unlock()
+
+	s.publish(uri, nil)
+}
+
+func (s *lspServer) scheduleAnalysis(uri string, delay time.Duration) {
+	filename, ok := uriToFilename(uri)
+	if !ok || !strings.HasSuffix(filename, ".go") {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.timers[filename]; ok {
+		t.Stop()
+	}
+
+	s.timers[filename] = time.AfterFunc(delay, func() {
+		s.analyze(uri, filename)
+	})
+}
+
+func (s *lspServer) analyze(uri, filename string) {
+	// Loading packages is expensive, so analyze one document at a time.
+	s.analyzeMu.Lock()
+	defer s.analyzeMu.Unlock()
+
+	s.mu.Lock()
+	overlay := make(map[string][]byte, len(s.docs))
+	for name, text := range s.docs {
+		overlay[name] = text
+	}
+	s.mu.Unlock()
+
+	s.driver.overlay = overlay
+
+	pkgs, err := s.driver.load([]string{"file=" + filename})
+	if err != nil {
+		s.logMessage(fmt.Sprintf("load %s: %v", filename, err))
+		return
+	}
+
+	issues, err := s.driver.analyze(pkgs)
+	if err != nil {
+		s.logMessage(fmt.Sprintf("analyze %s: %v", filename, err))
+		return
+	}
+
+	diags := make([]lspDiagnostic, 0, len(issues))
+	for _, is := range issues {
+		if is.pos.Filename != filename {
+			continue
+		}
+
+		diag := lspDiagnostic{
+			Range:    s.toRange(overlay, is.pos.Filename, is.pos.Line, is.pos.Column, is.end.Line, is.end.Column),
+			Severity: lspSeverityWarning,
+			Source:   s.driver.analyzer.Name,
+			Message:  is.diag.Message,
+		}
+		if strings.Contains(is.diag.Message, "(low severity:") {
+			diag.Severity = lspSeverityInformation
+		}
+
+		for _, rel := range is.diag.Related {
+			start := is.fset.Position(rel.Pos)
+			end := start
+			if rel.End.IsValid() {
+				end = is.fset.Position(rel.End)
+			}
+
+			diag.RelatedInformation = append(diag.RelatedInformation, lspRelatedInformation{
+				Location: lspLocation{
+					URI:   filenameToURI(start.Filename),
+					Range: s.toRange(overlay, start.Filename, start.Line, start.Column, end.Line, end.Column),
+				},
+				Message: rel.Message,
+			})
+		}
+
+		for _, fix := range is.diag.SuggestedFixes {
+			f := lspFix{title: fix.Message, edits: make(map[string][]lspTextEdit)}
+			for _, edit := range fix.TextEdits {
+				start := is.fset.Position(edit.Pos)
+				end := start
+				if edit.End.IsValid() {
+					end = is.fset.Position(edit.End)
+				}
+
+				editURI := filenameToURI(start.Filename)
+				f.edits[editURI] = append(f.edits[editURI], lspTextEdit{
+					Range:   s.toRange(overlay, start.Filename, start.Line, start.Column, end.Line, end.Column),
+					NewText: string(edit.NewText),
+				})
+			}
+			diag.fixes = append(diag.fixes, f)
+		}
+
+		diags = append(diags, diag)
+	}
+
+	s.mu.Lock()
+	_, open := s.docs[filename]
+	if open {
+		s.diagnostics[filename] = diags
+	}
+	s.mu.Unlock()
+
+	if open {
+		s.publish(uri, diags)
+	}
+}
+
+func (s *lspServer) publish(uri string, diags []lspDiagnostic) {
+	if diags == nil {
+		diags = []lspDiagnostic{}
+	}
+
+	params, _ := json.Marshal(map[string]any{
+		"uri":         uri,
+		"diagnostics": diags,
+	})
+
+	s.write(&lspMessage{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics", Params: params})
+}
+
+func (s *lspServer) logMessage(text string) {
+	params, _ := json.Marshal(map[string]any{
+		"type":    lspSeverityWarning,
+		"message": text,
+	})
+
+	s.write(&lspMessage{JSONRPC: "2.0", Method: "window/logMessage", Params: params})
+}
+
+func (s *lspServer) codeActions(params lspCodeActionParams) []map[string]any {
+	actions := []map[string]any{}
+
+	filename, ok := uriToFilename(params.TextDocument.URI)
+	if !ok {
+		return actions
+	}
+
+	s.mu.Lock()
+	diags := s.diagnostics[filename]
+	s.mu.Unlock()
+
+	for _, diag := range diags {
+		if !rangesOverlap(diag.Range, params.Range) {
+			continue
+		}
+
+		for _, fix := range diag.fixes {
+			actions = append(actions, map[string]any{
+				"title":       fix.title,
+				"kind":        "quickfix",
+				"diagnostics": []lspDiagnostic{diag},
+				"isPreferred": true,
+				"edit": map[string]any{
+					"changes": fix.edits,
+				},
+			})
+		}
+	}
+
+	return actions
+}
+
+// toRange converts 1-based byte positions to the 0-based LSP range in UTF-16 code units.
+func (s *lspServer) toRange(overlay map[string][]byte, filename string, line, col, endLine, endCol int) lspRange {
+	src, ok := overlay[filename]
+	if !ok {
+		src, _ = os.ReadFile(filename)
+	}
+	lines := splitLines(src)
+
+	return lspRange{
+		Start: toLSPPosition(lines, line, col),
+		End:   toLSPPosition(lines, endLine, endCol),
+	}
+}
+
+func toLSPPosition(lines []string, line, col int) lspPosition {
+	if line < 1 {
+		return lspPosition{}
+	}
+
+	character := col - 1
+	if line <= len(lines) {
+		text := lines[line-1]
+		if character > len(text) {
+			character = len(text)
+		}
+		if character >= 0 {
+			character = len(utf16.Encode([]rune(text[:character])))
+		}
+	}
+
+	return lspPosition{Line: line - 1, Character: max(character, 0)}
+}
+
+func rangesOverlap(a, b lspRange) bool {
+	return !positionLess(a.End, b.Start) && !positionLess(b.End, a.Start)
+}
+
+func positionLess(a, b lspPosition) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+func (s *lspServer) read() (*lspMessage, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %w", err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+
+	var msg lspMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (s *lspServer) write(msg *lspMessage) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func uriToFilename(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+
+	return filepath.FromSlash(u.Path), true
+}
+
+func filenameToURI(filename string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()
+}
@@ -1,11 +1,52 @@
 package main
 
 import (
+	"os"
+	"strings"
+
 	"golang.org/x/tools/go/analysis/singlechecker"
 
-	"github.com/ghostiam/protogetter"
+	"github.com/ghostiam/protogetter/v2"
 )
 
 func main() {
-	singlechecker.Main(protogetter.NewAnalyzer(nil))
+	args := os.Args[1:]
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "explain":
+			os.Exit(explain(args[1:]))
+		case "config":
+			os.Exit(configCheck(args[1:]))
+		case "corpus":
+			os.Exit(corpus(args[1:]))
+		case "completion":
+			os.Exit(completion(args[1:]))
+		case "lsp":
+			os.Exit(run(args[1:], modeLSP))
+		}
+	}
+
+	if isVetTool(args) {
+		// The unitchecker protocol of `go vet -vettool=$(which protogetter)`.
+		singlechecker.Main(protogetter.NewAnalyzer(nil))
+	}
+
+	os.Exit(run(args, modeCheck))
+}
+
+// isVetTool reports whether the binary is invoked by go vet.
+func isVetTool(args []string) bool {
+	if len(args) == 1 && strings.HasSuffix(args[0], ".cfg") {
+		return true
+	}
+
+	for _, arg := range args {
+		switch strings.TrimLeft(arg, "-") {
+		case "V=full", "flags":
+			return true
+		}
+	}
+
+	return false
 }
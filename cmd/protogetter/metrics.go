@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metrics collects the cost of a run, so that it can be monitored across many repositories.
+// All methods are safe for concurrent use and do nothing on a nil receiver.
+type metrics struct {
+	mu sync.Mutex
+
+	start    time.Time
+	packages map[string]bool
+	phases   map[string]time.Duration
+	rules    map[string]int
+	issues   int
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		start:    time.Now(),
+		packages: make(map[string]bool),
+		phases:   make(map[string]time.Duration),
+		rules:    make(map[string]int),
+	}
+}
+
+// phase adds the time elapsed since start to the phase, use as defer m.phase("load", time.Now()).
+func (m *metrics) phase(name string, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	elapsed := time.Since(start)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.phases[name] += elapsed
+}
+
+func (m *metrics) addPackage(id string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.packages[id] = true
+}
+
+// setIssues records the reported issues, it replaces the previously recorded ones.
+func (m *metrics) setIssues(issues []issue) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.issues = len(issues)
+	clear(m.rules)
+	for _, is := range issues {
+		m.rules[issueRule(is)]++
+	}
+}
+
+// issueRule returns the rule identifier the message starts with.
+func issueRule(is issue) string {
+	if rule, _, ok := strings.Cut(is.diag.Message, ": "); ok && strings.HasPrefix(rule, "PGL") {
+		return rule
+	}
+
+	return "unknown"
+}
+
+// jsonMetrics is the output of -metrics. Durations are in milliseconds, durations of phases are summed over
+// package groups processed in parallel, so they may exceed the total duration.
+type jsonMetrics struct {
+	Packages       int                `json:"packages"`
+	Issues         int                `json:"issues"`
+	IssuesByRule   map[string]int     `json:"issues_by_rule"`
+	DurationMS     float64            `json:"duration_ms"`
+	PhaseDurations map[string]float64 `json:"phase_durations_ms"`
+}
+
+// write writes the metrics as JSON to the file, or to stdout if the filename is "-".
+func (m *metrics) write(filename string) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	out := jsonMetrics{
+		Packages:       len(m.packages),
+		Issues:         m.issues,
+		IssuesByRule:   make(map[string]int, len(m.rules)),
+		DurationMS:     milliseconds(time.Since(m.start)),
+		PhaseDurations: make(map[string]float64, len(m.phases)),
+	}
+	for rule, n := range m.rules {
+		out.IssuesByRule[rule] = n
+	}
+	for name, d := range m.phases {
+		out.PhaseDurations[name] = milliseconds(d)
+	}
+	m.mu.Unlock()
+
+	data, err := json.MarshalIndent(out, "", "\t")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if filename == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0o644)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
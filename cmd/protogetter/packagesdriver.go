@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// externalDriver returns the external package driver, which go/packages runs instead of the go command
+// (for example, for Bazel or Please workspaces), empty if there is none. Like go/packages, it is
+// GOPACKAGESDRIVER or gopackagesdriver found in PATH, unless GOPACKAGESDRIVER=off.
+func externalDriver() string {
+	driver := os.Getenv("GOPACKAGESDRIVER")
+	switch driver {
+	case "off":
+		return ""
+	case "":
+		path, err := exec.LookPath("gopackagesdriver")
+		if err != nil {
+			return ""
+		}
+		return path
+	}
+
+	return driver
+}
+
+// filePatterns returns the patterns loading the packages of the files: their directories with the go command,
+// and file= queries with external drivers, which do not resolve directories to packages.
+func filePatterns(files map[string]bool) []string {
+	seen := make(map[string]bool)
+
+	var patterns []string
+	for filename := range files {
+		pattern := filepath.Dir(filename)
+		if externalDriver() != "" {
+			pattern = "file=" + filename
+		}
+
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+
+	return patterns
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadAndAnalyze loads and analyzes the packages matched by the patterns. Root packages which do not import
+// each other are split into groups, which are loaded concurrently, and each group is analyzed as soon as it is
+// loaded, so type information of all packages is never kept in memory at once.
+func (d *driver) loadAndAnalyze(patterns []string) ([]issue, error) {
+	groups := d.packageGroups(patterns)
+	if len(groups) <= 1 || d.opts.Parallel <= 1 {
+		pkgs, err := d.load(patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		return d.analyze(pkgs)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		issues   []issue
+		firstErr error
+	)
+
+	sem := make(chan struct{}, d.opts.Parallel)
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group []string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			groupIssues, err := d.loadAndAnalyzeGroup(group)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			issues = append(issues, groupIssues...)
+		}(group)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return sortIssues(issues), nil
+}
+
+func (d *driver) loadAndAnalyzeGroup(pkgPaths []string) ([]issue, error) {
+	pkgs, err := d.load(pkgPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.analyze(pkgs)
+}
+
+// packageGroups returns import paths of the root packages matched by the patterns, grouped so that packages
+// which import each other are in the same group. It returns nil if the metadata can not be loaded,
+// in which case the packages should be loaded at once to report the errors.
+func (d *driver) packageGroups(patterns []string) [][]string {
+	// External drivers build the packages themselves, usually with a single build server.
+	if d.opts.Parallel <= 1 || externalDriver() != "" {
+		return nil
+	}
+
+	defer d.metrics.phase("group", time.Now())
+
+	cfg := &packages.Config{
+		Mode:       packages.NeedName | packages.NeedImports,
+		Dir:        d.dir,
+		Env:        d.variant.environ(),
+		BuildFlags: d.variant.buildFlags,
+		Overlay:    d.overlay,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil
+	}
+
+	// Union-find over the root packages.
+	parent := make(map[string]string, len(pkgs))
+	var find func(string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil
+		}
+		parent[pkg.PkgPath] = pkg.PkgPath
+	}
+
+	for _, pkg := range pkgs {
+		for path := range pkg.Imports {
+			if _, ok := parent[path]; ok {
+				parent[find(pkg.PkgPath)] = find(path)
+			}
+		}
+	}
+
+	byRoot := make(map[string][]string)
+	for _, pkg := range pkgs {
+		root := find(pkg.PkgPath)
+		byRoot[root] = append(byRoot[root], pkg.PkgPath)
+	}
+
+	groups := make([][]string, 0, len(byRoot))
+	for _, group := range byRoot {
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+
+	// Start with the largest groups, they take the most time.
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return groups[i][0] < groups[j][0]
+	})
+
+	return groups
+}
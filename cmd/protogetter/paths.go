@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Path modes of the -path-mode flag.
+const (
+	pathModeAbsolute = "absolute"
+	pathModeRelative = "relative"
+)
+
+var pathModes = []string{pathModeAbsolute, pathModeRelative}
+
+// pathFormat rewrites file paths in the output, for CI annotation tools expecting paths relative to
+// the root of the repository. A nil pathFormat keeps the paths absolute.
+type pathFormat struct {
+	// base is the directory relative paths are resolved against, empty for absolute paths.
+	base string
+	trim string
+}
+
+func newPathFormat(mode, trim string) (*pathFormat, error) {
+	p := &pathFormat{trim: trim}
+
+	switch mode {
+	case pathModeAbsolute:
+	case pathModeRelative:
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		p.base = wd
+	default:
+		return nil, fmt.Errorf("unknown path mode %q, expected one of: %s", mode, strings.Join(pathModes, ", "))
+	}
+
+	if p.base == "" && p.trim == "" {
+		return nil, nil
+	}
+
+	return p, nil
+}
+
+// path returns the filename as it is printed.
+func (p *pathFormat) path(filename string) string {
+	if p == nil || filename == "" {
+		return filename
+	}
+
+	if p.base != "" {
+		if rel, err := filepath.Rel(p.base, filename); err == nil {
+			filename = rel
+		}
+	}
+
+	if p.trim != "" {
+		if trimmed, ok := strings.CutPrefix(filename, p.trim); ok {
+			filename = strings.TrimLeft(trimmed, `/\`)
+		}
+	}
+
+	return filename
+}
+
+// position returns the position as it is printed.
+func (p *pathFormat) position(pos token.Position) string {
+	pos.Filename = p.path(pos.Filename)
+	return pos.String()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// startProfiling starts CPU profiling and execution tracing if requested. The returned function stops them and
+// writes the heap profile, it must be called before the exit.
+func startProfiling(opts *options) (func(), error) {
+	var stops []func()
+	stop := func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+
+	if opts.CPUProfile != "" {
+		f, err := os.Create(opts.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		stops = append(stops, func() {
+			pprof.StopCPUProfile()
+			f.Close()
+		})
+	}
+
+	if opts.Trace != "" {
+		f, err := os.Create(opts.Trace)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			stop()
+			return nil, err
+		}
+
+		stops = append(stops, func() {
+			trace.Stop()
+			f.Close()
+		})
+	}
+
+	if opts.MemProfile != "" {
+		stops = append(stops, func() {
+			if err := writeMemProfile(opts.MemProfile); err != nil {
+				fmt.Fprintf(os.Stderr, "memprofile: %v\n", err)
+			}
+		})
+	}
+
+	return stop, nil
+}
+
+func writeMemProfile(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Get up-to-date statistics.
+	runtime.GC()
+
+	return pprof.WriteHeapProfile(f)
+}
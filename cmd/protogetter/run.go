@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+const (
+	exitOK     = 0
+	exitError  = 1
+	exitIssues = 3
+)
+
+type mode int
+
+const (
+	modeCheck mode = iota
+	modeLSP
+)
+
+type options struct {
+	Fix         bool
+	Interactive bool
+	Verify      bool
+	JSON        bool
+	Format      string
+	Context     int
+	Tests       bool
+	Watch       bool
+	// Parallel is the number of package groups loaded and analyzed concurrently.
+	Parallel int
+	Files    bool
+	Version  bool
+
+	ListFiles    bool
+	ListPackages bool
+	APISummary   bool
+
+	CPUProfile string
+	MemProfile string
+	Trace      string
+
+	Stdin         bool
+	StdinFilename string
+
+	Metrics string
+	Config  string
+
+	NewFromPatch string
+	NewFromRev   string
+
+	Variants variantsFlag
+
+	PathMode       string
+	PathPrefixTrim string
+
+	Quiet   bool
+	Summary bool
+
+	FailOnlyFixable bool
+}
+
+func run(args []string, m mode) int {
+	cfg := &protogetter.Config{}
+	analyzer := protogetter.NewAnalyzer(cfg)
+
+	opts := &options{}
+
+	fs := newFlagSet(analyzer, opts)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	if !slices.Contains(formats, opts.Format) {
+		fmt.Fprintf(os.Stderr, "%s: unknown format %q, expected one of: %s\n",
+			analyzer.Name, opts.Format, strings.Join(formats, ", "))
+		return exitError
+	}
+
+	if opts.Version {
+		printVersion(os.Stdout, analyzer.Name)
+		return exitOK
+	}
+
+	if filename := configFile(opts.Config); filename != "" {
+		if err := applyConfig(filename, fs, &analyzer.Flags); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+	}
+
+	stopProfiling, err := startProfiling(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+		return exitError
+	}
+	defer stopProfiling()
+
+	if m == modeLSP {
+		return runLSP(analyzer, opts)
+	}
+
+	d := newDriver(analyzer, opts)
+	if opts.Metrics != "" {
+		d.metrics = newMetrics()
+		defer func() {
+			if err := d.metrics.write(opts.Metrics); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			}
+		}()
+	}
+
+	d.paths, err = newPathFormat(opts.PathMode, opts.PathPrefixTrim)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+		return exitError
+	}
+
+	d.changes, err = loadChanges(".", opts.NewFromPatch, opts.NewFromRev)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+		return exitError
+	}
+
+	if opts.Interactive && (!opts.Fix || opts.Stdin || opts.Watch || opts.JSON) {
+		fmt.Fprintf(os.Stderr, "%s: -interactive requires -fix and can not be used with -stdin, -watch or -json\n",
+			analyzer.Name)
+		return exitError
+	}
+
+	if opts.Stdin && opts.Files {
+		fmt.Fprintf(os.Stderr, "%s: -files can not be used with -stdin\n", analyzer.Name)
+		return exitError
+	}
+
+	if opts.Files {
+		code, err := d.runFiles(fs.Args(), os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+		return code
+	}
+
+	if opts.Stdin {
+		code, err := d.runStdin(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+		return code
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	if opts.ListFiles || opts.ListPackages {
+		if err := list(os.Stdout, cfg, opts, fs.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	if opts.APISummary {
+		if err := apiSummary(os.Stdout, cfg, opts, fs.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	if opts.Watch {
+		if err := d.watch(fs.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	code, err := d.run(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", analyzer.Name, err)
+		return exitError
+	}
+
+	return code
+}
+
+// newFlagSet returns the flags of the command line, including the flags of the analyzer.
+func newFlagSet(analyzer *analysis.Analyzer, opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet("protogetter", flag.ContinueOnError)
+	fs.BoolVar(&opts.Version, "version", false, "print version and build information and exit")
+	fs.StringVar(&opts.Config, "config", "", "config file (default: "+defaultConfigFile+" in the current directory if exists)")
+	fs.BoolVar(&opts.Fix, "fix", false, "apply all suggested fixes")
+	fs.BoolVar(&opts.Interactive, "interactive", false,
+		"with -fix, show each fix and ask whether to apply it (y - yes, n - no, a - all remaining, q - quit)")
+	fs.BoolVar(&opts.Verify, "verify", true,
+		"with -fix, type-check and re-analyze modified packages to report fixes which broke compilation or did not converge")
+	fs.BoolVar(&opts.JSON, "json", false, "emit JSON output")
+	fs.StringVar(&opts.Format, "format", formatText,
+		"output format: "+strings.Join(formats, ", ")+" (ignored with -json)")
+	fs.StringVar(&opts.PathMode, "path-mode", pathModeAbsolute,
+		"paths of files in the output: "+strings.Join(pathModes, ", ")+" (relative to the current directory)")
+	fs.StringVar(&opts.PathPrefixTrim, "path-prefix-trim", "", "trim this prefix from paths of files in the output")
+	fs.BoolVar(&opts.Quiet, "quiet", false, "do not print issues, only set the exit code")
+	fs.BoolVar(&opts.Summary, "summary", false, "with -quiet, print the number of issues and files in one line")
+	fs.BoolVar(&opts.FailOnlyFixable, "fail-only-fixable", false,
+		"exit with a non-zero code only for issues with suggested fixes, other issues are printed as warnings")
+	fs.IntVar(&opts.Context, "c", -1, "display offending line with this many lines of context")
+	fs.BoolVar(&opts.Tests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.IntVar(&opts.Parallel, "p", runtime.GOMAXPROCS(0),
+		"number of independent package groups loaded and analyzed in parallel")
+	fs.BoolVar(&opts.Watch, "watch", false, "re-analyze affected packages when files change")
+	fs.BoolVar(&opts.Files, "files", false,
+		"analyze only the packages containing the given .go files and report issues of these files, "+
+			"without arguments the NUL or newline separated list is read from stdin")
+	fs.BoolVar(&opts.ListFiles, "list-files", false,
+		"print files which would be analyzed after skipping generated and excluded ones, without running checks")
+	fs.BoolVar(&opts.ListPackages, "list-packages", false,
+		"print packages which would be analyzed after skipping generated and excluded files, without running checks")
+	fs.BoolVar(&opts.APISummary, "api-summary", false,
+		"print packages which access fields of open and hybrid API messages directly, without running checks")
+	fs.BoolVar(&opts.Stdin, "stdin", false, "read contents of the file from stdin, types are resolved from its package on disk")
+	fs.StringVar(&opts.StdinFilename, "stdin-filename", "", "path of the file read from stdin")
+	fs.StringVar(&opts.CPUProfile, "cpuprofile", "", "write CPU profile to this file")
+	fs.StringVar(&opts.MemProfile, "memprofile", "", "write memory profile to this file")
+	fs.StringVar(&opts.Trace, "trace", "", "write trace log to this file")
+	fs.StringVar(&opts.Metrics, "metrics", "",
+		"write run metrics (packages, issues by rule, duration per phase) as JSON to this file, - for stdout")
+	fs.StringVar(&opts.NewFromPatch, "new-from-patch", "",
+		"report only issues on lines added or modified by this unified diff file")
+	fs.StringVar(&opts.NewFromRev, "new-from-rev", "",
+		"report only issues on lines added or modified since this git revision, including uncommitted changes")
+	fs.Var(&opts.Variants, "variant",
+		"analyze in this build configuration, like \"GOOS=windows tags=integration\", "+
+			"repeat to analyze several configurations, issues of shared files are reported once")
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "%s: %s\n\n", analyzer.Name, analyzer.Doc)
+		fmt.Fprintf(fs.Output(), "Usage: %s [-flag] [package]\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s -stdin -stdin-filename=path/to/file.go < contents\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s -files [file.go...]\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s lsp [-flag]\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s explain [rule]\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s config check [-config=file] [-flag]\n", analyzer.Name)
+		fmt.Fprintf(fs.Output(), "       %s completion bash|zsh|fish\n\n", analyzer.Name)
+		fmt.Fprintln(fs.Output(), "Flags:")
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
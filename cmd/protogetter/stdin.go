@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+)
+
+// runStdin analyzes contents of a single file read from stdin (for example, an unsaved editor buffer).
+// The file replaces the file on disk in its package, so types are resolved from the surrounding package.
+func (d *driver) runStdin(r io.Reader) (int, error) {
+	if d.opts.StdinFilename == "" {
+		return exitError, errors.New("-stdin-filename is required with -stdin")
+	}
+
+	if d.opts.Fix {
+		return exitError, errors.New("-fix is not supported with -stdin")
+	}
+
+	filename, err := filepath.Abs(d.opts.StdinFilename)
+	if err != nil {
+		return exitError, err
+	}
+
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return exitError, err
+	}
+
+	d.overlay = map[string][]byte{filename: src}
+
+	pkgs, err := d.load([]string{"file=" + filename})
+	if err != nil {
+		return exitError, err
+	}
+
+	issues, err := d.analyze(pkgs)
+	if err != nil {
+		return exitError, err
+	}
+
+	// Report only issues of the file itself.
+	var fileIssues []issue
+	for _, is := range issues {
+		if is.pos.Filename == filename {
+			fileIssues = append(fileIssues, is)
+		}
+	}
+
+	return d.report(fileIssues)
+}
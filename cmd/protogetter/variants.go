@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// buildVariant is a build configuration in which the packages are loaded, like another GOOS or build tags.
+type buildVariant struct {
+	spec       string
+	env        []string
+	buildFlags []string
+}
+
+// parseVariant parses the space separated list of environment variables (GOOS=windows) and build tags
+// (tags=integration,e2e) of the variant.
+func parseVariant(spec string) (buildVariant, error) {
+	v := buildVariant{spec: spec}
+	for _, field := range strings.Fields(spec) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return buildVariant{}, fmt.Errorf("invalid variant %q: expected KEY=VALUE or tags=a,b, got %q", spec, field)
+		}
+
+		if key == "tags" {
+			v.buildFlags = append(v.buildFlags, "-tags="+value)
+			continue
+		}
+
+		v.env = append(v.env, key+"="+value)
+	}
+
+	if len(v.env) == 0 && len(v.buildFlags) == 0 {
+		return buildVariant{}, fmt.Errorf("invalid variant %q: empty", spec)
+	}
+
+	return v, nil
+}
+
+// environ returns the environment of the go command for the variant, nil (the current environment) if it is empty.
+func (v buildVariant) environ() []string {
+	if len(v.env) == 0 {
+		return nil
+	}
+
+	return append(os.Environ(), v.env...)
+}
+
+// variantsFlag is a repeated flag of build variants.
+type variantsFlag []buildVariant
+
+func (f *variantsFlag) String() string {
+	specs := make([]string, 0, len(*f))
+	for _, v := range *f {
+		specs = append(specs, v.spec)
+	}
+
+	return strings.Join(specs, "; ")
+}
+
+func (f *variantsFlag) Set(spec string) error {
+	v, err := parseVariant(spec)
+	if err != nil {
+		return err
+	}
+
+	*f = append(*f, v)
+	return nil
+}
+
+// forEachVariant calls fn once for each build variant with the driver configured to load packages in it,
+// or once in the default configuration if there are no variants. Issues of files shared by the variants
+// are identical, so they are merged by sortIssues.
+func (d *driver) forEachVariant(fn func() error) error {
+	if len(d.opts.Variants) == 0 {
+		return fn()
+	}
+
+	defer func() { d.variant = buildVariant{} }()
+
+	for _, v := range d.opts.Variants {
+		d.variant = v
+		if err := fn(); err != nil {
+			if len(d.opts.Variants) > 1 {
+				return fmt.Errorf("variant %q: %w", v.spec, err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// verifyFixes type-checks and re-analyzes the packages of the files modified by fixes. It reports files
+// which do not compile anymore and fixes which failed to converge (the fixed files still have fixable issues),
+// and returns false if there are any.
+func (d *driver) verifyFixes(modified []string) (bool, error) {
+	defer d.metrics.phase("verify", time.Now())
+
+	files := make(map[string]bool, len(modified))
+	for _, filename := range modified {
+		files[filename] = true
+	}
+	patterns := filePatterns(files)
+
+	ok := true
+	broken := make(map[string]bool)
+	diverged := make(map[string]bool)
+
+	err := d.forEachVariant(func() error {
+		pkgs, err := d.load(patterns)
+		if err != nil {
+			return err
+		}
+
+		for _, pkg := range pkgs {
+			for _, err := range pkg.Errors {
+				if m := errorPosRe.FindStringSubmatch(err.Pos); m != nil && files[m[1]] && !broken[m[1]] {
+					broken[m[1]] = true
+					fmt.Fprintf(os.Stderr, "%s: %s: does not compile after fixes\n", d.analyzer.Name, m[1])
+					ok = false
+				}
+			}
+		}
+
+		issues, err := d.analyze(pkgs)
+		if err != nil {
+			return err
+		}
+
+		for _, is := range issues {
+			key := is.pos.String() + ": " + is.diag.Message
+			if files[is.pos.Filename] && len(is.diag.SuggestedFixes) > 0 && !diverged[key] {
+				diverged[key] = true
+				fmt.Fprintf(os.Stderr, "%s: %s: fix did not converge: %s\n", d.analyzer.Name, is.pos, is.diag.Message)
+				ok = false
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
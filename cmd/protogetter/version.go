@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+)
+
+// version is set by the release build via -ldflags "-X main.version=...".
+var version = ""
+
+// protobufCompatibility describes the generated code which the linter recognizes as proto messages.
+var protobufCompatibility = []string{
+	"google.golang.org/protobuf (APIv2, ProtoReflect)",
+	"github.com/golang/protobuf (APIv1, ProtoMessage)",
+	"github.com/planetscale/vtprotobuf",
+	"github.com/gogo/protobuf messages are skipped",
+}
+
+func printVersion(w io.Writer, name string) {
+	v := version
+	var revision, modified, buildTime string
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" {
+			v = info.Main.Version
+		}
+
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.modified":
+				if s.Value == "true" {
+					modified = " (modified)"
+				}
+			case "vcs.time":
+				buildTime = s.Value
+			}
+		}
+	}
+
+	if v == "" {
+		v = "(devel)"
+	}
+
+	fmt.Fprintf(w, "%s %s\n", name, v)
+	if revision != "" {
+		fmt.Fprintf(w, "  revision: %s%s\n", revision, modified)
+	}
+	if buildTime != "" {
+		fmt.Fprintf(w, "  time:     %s\n", buildTime)
+	}
+	fmt.Fprintf(w, "  go:       %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if driver := externalDriver(); driver != "" {
+		fmt.Fprintf(w, "  driver:   %s\n", driver)
+	}
+	fmt.Fprintln(w, "  protobuf:")
+	for _, c := range protobufCompatibility {
+		fmt.Fprintf(w, "    %s\n", c)
+	}
+}
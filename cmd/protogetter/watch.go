@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/tools/go/packages"
+)
+
+// watchDebounce is the time to wait for more changes before re-analyzing, since editors write files in several steps.
+const watchDebounce = 300 * time.Millisecond
+
+// watch analyzes the packages and then re-analyzes affected packages when their files change.
+func (d *driver) watch(patterns []string) error {
+	pkgs, err := d.load(patterns)
+	if err != nil {
+		return err
+	}
+
+	if err := d.analyzeAndPrint(pkgs); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, dir := range packageDirs(pkgs) {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	changed := make(map[string]struct{})
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !strings.HasSuffix(event.Name, ".go") || event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			changed[filepath.Dir(event.Name)] = struct{}{}
+			timer.Reset(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "%s: watch: %v\n", d.analyzer.Name, err)
+
+		case <-timer.C:
+			dirs := make([]string, 0, len(changed))
+			for dir := range changed {
+				dirs = append(dirs, dir)
+			}
+			sort.Strings(dirs)
+			clear(changed)
+
+			fmt.Fprintf(d.out, "[%s] re-analyzing %s\n", time.Now().Format(time.TimeOnly), strings.Join(dirs, ", "))
+
+			pkgs, err := d.load(dirs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", d.analyzer.Name, err)
+				continue
+			}
+
+			if err := d.analyzeAndPrint(pkgs); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", d.analyzer.Name, err)
+			}
+		}
+	}
+}
+
+func (d *driver) analyzeAndPrint(pkgs []*packages.Package) error {
+	issues, err := d.analyze(pkgs)
+	if err != nil {
+		return err
+	}
+
+	if err := d.print(issues); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(d.out, "[%s] %d issue(s) found, watching for changes...\n", time.Now().Format(time.TimeOnly), len(issues))
+	return nil
+}
+
+// packageDirs returns sorted unique directories of the packages files.
+func packageDirs(pkgs []*packages.Package) []string {
+	seen := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		for _, f := range pkg.GoFiles {
+			seen[filepath.Dir(f)] = struct{}{}
+		}
+	}
+
+	dirs := make([]string, 0, len(seen))
+	for dir := range seen {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+func main() {
+	analyzers := protogetter.Analyzers()
+	for _, a := range protogetter.OptInAnalyzers() {
+		if isEnabled(a, os.Args[1:]) {
+			analyzers = append(analyzers, a)
+		}
+	}
+
+	multichecker.Main(analyzers...)
+}
+
+// isEnabled reports whether the opt-in analyzer is enabled explicitly with -NAME or -NAME=true flag.
+func isEnabled(a *analysis.Analyzer, args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		arg = strings.TrimLeft(arg, "-")
+		if arg == a.Name || arg == a.Name+"=true" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// debugf prints the reason of a skip decision to stderr if the debug mode is enabled,
+// which helps to diagnose false negatives.
+func debugf(fset *token.FileSet, cfg *Config, pos token.Pos, format string, args ...any) {
+	if !cfg.Debug {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: debug: %s\n", fset.Position(pos), fmt.Sprintf(format, args...))
+}
+
+// notProtoMessageReason explains why the receiver of the field selector is not considered as a proto message.
+func notProtoMessageReason(info *types.Info, x *ast.SelectorExpr) string {
+	if !hasProtobufTag(info, x) {
+		return ""
+	}
+
+	recv := info.Selections[x].Recv()
+	if methodIsExists(info, x.X, "ProtoMessage") {
+		return fmt.Sprintf("skip %s: %s is generated by protoc-gen-gogo, which may not generate nil-safe getters",
+			formatNode(x), recv)
+	}
+
+	return fmt.Sprintf("skip %s: %s has protobuf tags, but is not a proto message", formatNode(x), recv)
+}
+
+// hasProtobufTag reports whether the selector reads a field with the protobuf struct tag. Only such fields
+// are expected to have getters, so other fields are not worth mentioning in the debug output.
+func hasProtobufTag(info *types.Info, x *ast.SelectorExpr) bool {
+	selection, ok := info.Selections[x]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+		return false
+	}
+
+	st, ok := derefType(selection.Recv()).Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	// Wrappers of oneof fields are not messages and have no getters.
+	tag, ok := reflect.StructTag(st.Tag(selection.Index()[0])).Lookup("protobuf")
+	return ok && !strings.HasSuffix(tag, ",oneof")
+}
@@ -0,0 +1,44 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// MessageDetector recognizes messages of in-house code generators, which do not implement the proto.Message
+// interface (for example, they are marked by other methods or wrapped into custom interfaces),
+// so that reads of their fields are reported as well.
+//
+// The detected messages must have a nil-safe Get<Field> getter for each reported field,
+// fields without getters are not reported.
+type MessageDetector interface {
+	// IsMessage reports whether the named type (not a pointer to it) is a message.
+	IsMessage(t *types.Named) bool
+}
+
+// MessageDetectorFunc is a function implementing MessageDetector.
+type MessageDetectorFunc func(t *types.Named) bool
+
+func (f MessageDetectorFunc) IsMessage(t *types.Named) bool {
+	return f(t)
+}
+
+// isDetectedMessage reports whether the type of the expression is recognized by one of the configured detectors.
+func isDetectedMessage(cfg *Config, info *types.Info, expr ast.Expr) bool {
+	if cfg == nil || len(cfg.MessageDetectors) == 0 {
+		return false
+	}
+
+	named, ok := typesNamed(info, expr)
+	if !ok {
+		return false
+	}
+
+	for _, d := range cfg.MessageDetectors {
+		if d.IsMessage(named) {
+			return true
+		}
+	}
+
+	return false
+}
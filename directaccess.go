@@ -0,0 +1,116 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const directAccessMsgFormat = ruleUnnecessaryGetter + ": unnecessary getter call %s on the message which is not nil, use %s instead"
+
+// NewDirectAccessAnalyzer returns an opt-in analyzer, which is the inverse of the main rule: it reports getter calls
+// on receivers which are provably not nil, for hot paths where direct field access is preferred.
+func NewDirectAccessAnalyzer(cfg *Config) *analysis.Analyzer {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	return &analysis.Analyzer{
+		Name:  "protodirect",
+		Doc:   "Reports unnecessary getter calls on proto messages which are provably not nil",
+		Flags: flags(cfg),
+		Run: func(pass *analysis.Pass) (any, error) {
+			err := RunDirectAccess(pass, cfg)
+			return nil, err
+		},
+	}
+}
+
+func RunDirectAccess(pass *analysis.Pass, cfg *Config) error {
+	files, err := filterFiles(pass, cfg)
+	if err != nil {
+		return err
+	}
+
+	flow := newNilFlow(pass.TypesInfo, files)
+
+	ins := inspector.New(files)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		if len(call.Args) != 0 {
+			return
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isGetterName(sel.Sel.Name) {
+			return
+		}
+
+		ident, ok := ast.Unparen(sel.X).(*ast.Ident)
+		if !ok || !isProtoMessage(cfg, pass.TypesInfo, ident) {
+			return
+		}
+
+		obj := pass.TypesInfo.Uses[ident]
+		if obj == nil || !flow.IsNonNil(obj, call.Pos()) {
+			return
+		}
+
+		field := strings.TrimPrefix(sel.Sel.Name, "Get")
+		if !getterReturnsField(pass.TypesInfo, sel, field) {
+			return
+		}
+
+		from := formatNode(call)
+		to := ident.Name + "." + field
+		msg := fmt.Sprintf(directAccessMsgFormat, from, to)
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					// Replace only the getter call, keeping the receiver and comments around it as is.
+					TextEdits: []analysis.TextEdit{
+						{
+							Pos:     sel.Sel.Pos(),
+							End:     call.End(),
+							NewText: []byte(field),
+						},
+					},
+				},
+			},
+		})
+	})
+
+	return nil
+}
+
+// getterReturnsField reports whether the getter returns the field of the same type as is.
+// Getters of proto2 and optional fields dereference pointers, and getters of oneof fields have no field at all,
+// so they cannot be replaced with direct access.
+func getterReturnsField(info *types.Info, getter *ast.SelectorExpr, field string) bool {
+	selection, ok := info.Selections[getter]
+	if !ok || selection.Kind() != types.MethodVal {
+		return false
+	}
+
+	sig, ok := selection.Type().(*types.Signature)
+	if !ok || sig.Results().Len() != 1 {
+		return false
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(selection.Recv(), true, selection.Obj().Pkg(), field)
+	v, ok := obj.(*types.Var)
+	if !ok || !v.IsField() {
+		return false
+	}
+
+	return types.Identical(v.Type(), sig.Results().At(0).Type())
+}
@@ -0,0 +1,13 @@
+// Package protogetter provides analyzers which enforce the use of getters for reading proto message fields,
+// and related checks of proto message usage.
+//
+// The API of the package is semver-stable within the major version:
+//   - NewAnalyzer and the other New*Analyzer constructors, Analyzers and OptInAnalyzers;
+//   - Config with the options of the analyzers, and MessageDetector for custom message types;
+//   - Run and the other Run* functions, for drivers which run the checks without the analysis framework;
+//   - Report, Severity and NilReturnFact;
+//   - API and MessageAPI;
+//   - Rule, Rules, ExplainRule and FilterFiles.
+//
+// Diagnostic messages are not a part of the API, use the rule identifiers at the start of the messages instead.
+package protogetter
@@ -0,0 +1,61 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	enumNameMapMsgFormat  = ruleEnumMap + ": avoid reading enum name map %s, use the String method of %s instead"
+	enumValueMapMsgFormat = ruleEnumMap + ": avoid reading enum value map %s, " +
+		"use the enum descriptor instead, like %s(0).Descriptor().Values().ByName(name)"
+)
+
+// checkEnumMap reports uses of the <Enum>_name and <Enum>_value maps generated for proto enums.
+// The maps are kept for compatibility only: they know nothing about aliases and cannot be used with the descriptors.
+func checkEnumMap(pass *analysis.Pass, ident *ast.Ident) {
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return
+	}
+
+	format := enumNameMapMsgFormat
+	enumName, ok := strings.CutSuffix(obj.Name(), "_name")
+	if !ok {
+		format = enumValueMapMsgFormat
+		enumName, ok = strings.CutSuffix(obj.Name(), "_value")
+	}
+	if !ok {
+		return
+	}
+
+	enum, ok := obj.Pkg().Scope().Lookup(enumName).(*types.TypeName)
+	if !ok || !isProtoEnum(enum.Type()) {
+		return
+	}
+
+	if _, ok := obj.Type().Underlying().(*types.Map); !ok {
+		return
+	}
+
+	qualified := obj.Name()
+	if obj.Pkg() != pass.Pkg {
+		qualified = obj.Pkg().Name() + "." + qualified
+		enumName = obj.Pkg().Name() + "." + enumName
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     ident.Pos(),
+		End:     ident.End(),
+		Message: fmt.Sprintf(format, qualified, enumName),
+	})
+}
+
+// isProtoEnum reports whether the type is an enum generated by protoc-gen-go.
+func isProtoEnum(t types.Type) bool {
+	return typeHasMethod(t, "Number") || typeHasMethod(t, "EnumDescriptor")
+}
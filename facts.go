@@ -0,0 +1,173 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// NilReturnFact is exported for functions that may return a nil proto message.
+// The fact is available for dependent packages, so accesses like f().Field can be ranked by the actual nil risk
+// even if f is declared in another package.
+type NilReturnFact struct{}
+
+func (*NilReturnFact) AFact() {}
+
+func (*NilReturnFact) String() string { return "mayReturnNil" }
+
+// exportNilReturnFacts exports NilReturnFact for the functions of the package which may return a nil proto message.
+func exportNilReturnFacts(pass *analysis.Pass, files []*ast.File) {
+	var decls []*ast.FuncDecl
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+
+			obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+			if !ok || len(protoMessageResults(obj)) == 0 || isProtoGetter(obj) {
+				continue
+			}
+
+			decls = append(decls, fn)
+		}
+	}
+
+	// Functions may return results of each other, so repeat until no new facts are exported.
+	for changed := true; changed; {
+		changed = false
+
+		for _, fn := range decls {
+			obj := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+			if pass.ImportObjectFact(obj, new(NilReturnFact)) {
+				continue
+			}
+
+			if mayReturnNil(pass, fn.Body, protoMessageResults(obj)) {
+				pass.ExportObjectFact(obj, new(NilReturnFact))
+				changed = true
+			}
+		}
+	}
+}
+
+// mayReturnNil reports whether any of the returns of the function body returns nil or a result of a call,
+// which may return nil, at one of the given result indexes.
+func mayReturnNil(pass *analysis.Pass, body *ast.BlockStmt, indexes []int) bool {
+	var found bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			// Returns of nested functions are not returns of the function itself.
+			return false
+
+		case *ast.ReturnStmt:
+			if len(x.Results) == 1 {
+				// Multiple results of a single call: return f()
+				if call, ok := ast.Unparen(x.Results[0]).(*ast.CallExpr); ok && isNilReturningCall(pass, call) {
+					found = true
+					return false
+				}
+			}
+
+			for _, i := range indexes {
+				if i >= len(x.Results) {
+					continue
+				}
+
+				result := ast.Unparen(x.Results[i])
+
+				if isNil(pass.TypesInfo, result) {
+					found = true
+					return false
+				}
+
+				if call, ok := result.(*ast.CallExpr); ok && isNilReturningCall(pass, call) {
+					found = true
+					return false
+				}
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+func isNilReturningCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return false
+	}
+
+	return pass.ImportObjectFact(fn, new(NilReturnFact))
+}
+
+// nilReturningCall returns the first call in the receiver chain of the expression,
+// which may return nil according to NilReturnFact.
+func nilReturningCall(pass *analysis.Pass, n ast.Node) (string, bool) {
+	var found string
+	for x := n; x != nil; {
+		switch e := x.(type) {
+		case *ast.SelectorExpr:
+			x = e.X
+		case *ast.StarExpr:
+			x = e.X
+		case *ast.ParenExpr:
+			x = e.X
+		case *ast.IndexExpr:
+			x = e.X
+		case *ast.CallExpr:
+			if isNilReturningCall(pass, e) {
+				found = formatNode(e.Fun)
+			}
+			x = e.Fun
+		default:
+			x = nil
+		}
+	}
+
+	return found, found != ""
+}
+
+// protoMessageResults returns indexes of the function results, which are pointers to proto messages.
+func protoMessageResults(fn *types.Func) []int {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return nil
+	}
+
+	var indexes []int
+	for i := 0; i < sig.Results().Len(); i++ {
+		t := sig.Results().At(i).Type()
+		if _, ok := t.(*types.Pointer); !ok {
+			continue
+		}
+
+		if typeHasMethod(t, "ProtoReflect") || typeHasMethod(t, "ProtoMessage") {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// isProtoGetter reports whether the function is a getter of a proto message.
+// Getters are expected to return nil and are suggested by the linter itself, so they are not ranked.
+func isProtoGetter(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || !isGetterName(fn.Name()) {
+		return false
+	}
+
+	recv := sig.Recv().Type()
+	return typeHasMethod(recv, "ProtoReflect") || typeHasMethod(recv, "ProtoMessage")
+}
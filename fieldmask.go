@@ -0,0 +1,169 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const fieldMaskPkgPath = "google.golang.org/protobuf/types/known/fieldmaskpb"
+
+const (
+	fieldMaskAppendMsgFormat = ruleFieldMaskManual + ": avoid manual append to FieldMask paths %s, use %s.Append(m, paths...) to validate paths against the message descriptor"
+	fieldMaskUnionMsgFormat  = ruleFieldMaskManual + ": avoid manual merging of FieldMask paths %s, use fieldmaskpb.Union(%s, %s) instead"
+	fieldMaskAssignMsgFormat = ruleFieldMaskManual + ": avoid manual assignment of FieldMask paths %s, use fieldmaskpb.New(m, paths...) to validate paths against the message descriptor"
+	fieldMaskLitMsg          = ruleFieldMaskManual + ": avoid manual construction of FieldMask paths, use fieldmaskpb.New(m, paths...) to validate paths against the message descriptor"
+)
+
+func NewFieldMaskAnalyzer(cfg *Config) *analysis.Analyzer {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	return &analysis.Analyzer{
+		Name:  "protofieldmask",
+		Doc:   "Reports manual manipulation of FieldMask paths when fieldmaskpb helpers should be used",
+		Flags: flags(cfg),
+		Run: func(pass *analysis.Pass) (any, error) {
+			err := RunFieldMask(pass, cfg)
+			return nil, err
+		},
+	}
+}
+
+func RunFieldMask(pass *analysis.Pass, cfg *Config) error {
+	files, err := filterFiles(pass, cfg)
+	if err != nil {
+		return err
+	}
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+	}
+
+	ins := inspector.New(files)
+	ins.Preorder(nodeTypes, func(node ast.Node) {
+		switch x := node.(type) {
+		case *ast.CallExpr:
+			if !isBuiltin(pass.TypesInfo, x.Fun, "append") || len(x.Args) == 0 {
+				return
+			}
+
+			mask, ok := fieldMaskPaths(pass.TypesInfo, x.Args[0])
+			if !ok {
+				return
+			}
+
+			if x.Ellipsis.IsValid() && len(x.Args) == 2 {
+				if other, ok := fieldMaskPaths(pass.TypesInfo, x.Args[1]); ok {
+					reportNodef(pass, x, fieldMaskUnionMsgFormat, formatNode(x), formatNode(mask), formatNode(other))
+					return
+				}
+			}
+
+			reportNodef(pass, x, fieldMaskAppendMsgFormat, formatNode(x.Args[0]), formatNode(mask))
+
+		case *ast.AssignStmt:
+			if x.Tok != token.ASSIGN || len(x.Lhs) != len(x.Rhs) {
+				return
+			}
+
+			for i, lhs := range x.Lhs {
+				if _, ok := lhs.(*ast.SelectorExpr); !ok {
+					continue
+				}
+
+				if _, ok := fieldMaskPaths(pass.TypesInfo, lhs); !ok {
+					continue
+				}
+
+				rhs := ast.Unparen(x.Rhs[i])
+				if isNil(pass.TypesInfo, rhs) {
+					continue
+				}
+
+				// Appends are reported separately.
+				if call, ok := rhs.(*ast.CallExpr); ok && isBuiltin(pass.TypesInfo, call.Fun, "append") {
+					continue
+				}
+
+				reportNodef(pass, lhs, fieldMaskAssignMsgFormat, formatNode(lhs))
+			}
+
+		case *ast.CompositeLit:
+			if !isFieldMask(pass.TypesInfo.TypeOf(x)) {
+				return
+			}
+
+			for _, elt := range x.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Paths" {
+					reportNodef(pass, x, fieldMaskLitMsg)
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// fieldMaskPaths returns the FieldMask if the expression is mask.Paths or mask.GetPaths().
+func fieldMaskPaths(info *types.Info, expr ast.Expr) (ast.Expr, bool) {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		if x.Sel.Name == "Paths" && isFieldMask(info.TypeOf(x.X)) {
+			return x.X, true
+		}
+
+	case *ast.CallExpr:
+		sel, ok := x.Fun.(*ast.SelectorExpr)
+		if ok && len(x.Args) == 0 && sel.Sel.Name == "GetPaths" && isFieldMask(info.TypeOf(sel.X)) {
+			return sel.X, true
+		}
+	}
+
+	return nil, false
+}
+
+func isFieldMask(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	named, ok := derefType(t).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == fieldMaskPkgPath && obj.Name() == "FieldMask"
+}
+
+func isBuiltin(info *types.Info, expr ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok || ident.Name != name {
+		return false
+	}
+
+	_, ok = info.Uses[ident].(*types.Builtin)
+	return ok
+}
+
+func isNil(info *types.Info, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, ok = info.Uses[ident].(*types.Nil)
+	return ok
+}
@@ -0,0 +1,193 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	structFormatMsgFormat = ruleStructFormat + ": avoid formatting proto message %s with %s, it prints the internal " +
+		"fields of the message, use prototext.Format(%s) or the String method instead"
+	structFormatFixMessage = "Use %v to format the message with its String method"
+)
+
+// printfFuncs are the printf-like functions and methods ("Type.Method") by package path,
+// with the index of the format argument.
+var printfFuncs = map[string]map[string]int{
+	"fmt": {
+		"Printf":  0,
+		"Sprintf": 0,
+		"Errorf":  0,
+		"Fprintf": 1,
+		"Appendf": 1,
+	},
+	"log": {
+		"Printf":        0,
+		"Fatalf":        0,
+		"Panicf":        0,
+		"Logger.Printf": 0,
+		"Logger.Fatalf": 0,
+		"Logger.Panicf": 0,
+	},
+}
+
+// printfDirective is a formatting directive of a printf format string.
+type printfDirective struct {
+	start, end int    // Byte offsets of the directive in the format string.
+	flags      string // Flags of the directive, like "+" or "#".
+	verb       rune
+	arg        int // Index of the argument relative to the format argument.
+}
+
+// checkStructFormat reports proto messages formatted by printf-like functions as Go structs:
+// messages with %#v and message values (not pointers, so the String method is not used) with %v, %+v and %s.
+// The fix replaces %#v with %v for pointers to messages, if the format is a string literal.
+func checkStructFormat(pass *analysis.Pass, cfg *Config, call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+
+	formatIdx, ok := printfFuncs[fn.Pkg().Path()][funcKey(fn)]
+	if !ok || formatIdx >= len(call.Args) {
+		return
+	}
+
+	formatArg := call.Args[formatIdx]
+	tv, ok := pass.TypesInfo.Types[formatArg]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+
+	directives, ok := parsePrintf(constant.StringVal(tv.Value))
+	if !ok {
+		return
+	}
+
+	// The fix edits the literal itself, so the directives must be at the same place in the source.
+	var rawDirectives []printfDirective
+	lit, _ := ast.Unparen(formatArg).(*ast.BasicLit)
+	if lit != nil && len(lit.Value) >= 2 {
+		rawDirectives, ok = parsePrintf(lit.Value[1 : len(lit.Value)-1])
+		if !ok || len(rawDirectives) != len(directives) {
+			rawDirectives = nil
+		}
+	}
+
+	for i, d := range directives {
+		argIdx := formatIdx + 1 + d.arg
+		if argIdx >= len(call.Args) {
+			return
+		}
+
+		arg := call.Args[argIdx]
+		t := pass.TypesInfo.TypeOf(arg)
+		if !isProtoMessageValue(cfg, t) {
+			continue
+		}
+
+		_, isPtr := t.Underlying().(*types.Pointer)
+		structVerb := d.verb == 'v' && strings.Contains(d.flags, "#")
+		if !isPtr {
+			if _, isNamed := types.Unalias(t).(*types.Named); !isNamed {
+				// Slices and arrays are formatted element by element.
+				continue
+			}
+
+			structVerb = structVerb || d.verb == 'v' || d.verb == 's'
+		}
+
+		if !structVerb {
+			continue
+		}
+
+		diag := analysis.Diagnostic{
+			Pos:     arg.Pos(),
+			End:     arg.End(),
+			Message: fmt.Sprintf(structFormatMsgFormat, formatNode(arg), "%"+d.flags+string(d.verb), formatNode(arg)),
+		}
+
+		if isPtr && rawDirectives != nil {
+			raw := rawDirectives[i]
+			text := lit.Value[1+raw.start : 1+raw.end]
+			base := lit.Pos() + 1
+
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{
+					Message: structFormatFixMessage,
+					TextEdits: []analysis.TextEdit{{
+						Pos:     base + token.Pos(raw.start),
+						End:     base + token.Pos(raw.end),
+						NewText: []byte(strings.ReplaceAll(text, "#", "")),
+					}},
+				},
+			}
+		}
+
+		pass.Report(diag)
+	}
+}
+
+// parsePrintf returns the directives of the format string consuming arguments.
+// It returns false for formats with explicit argument indexes, which are not supported.
+func parsePrintf(format string) ([]printfDirective, bool) {
+	var directives []printfDirective
+	arg := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		start := i
+		i++
+
+		flagsStart := i
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		flags := format[flagsStart:i]
+
+		// Width and precision, the stars consume arguments.
+		for i < len(format) && (format[i] >= '0' && format[i] <= '9' || format[i] == '.' || format[i] == '*') {
+			if format[i] == '*' {
+				arg++
+			}
+			i++
+		}
+
+		if i >= len(format) {
+			break
+		}
+
+		if format[i] == '[' {
+			return nil, false
+		}
+
+		if format[i] == '%' {
+			continue
+		}
+
+		directives = append(directives, printfDirective{
+			start: start,
+			end:   i + 1,
+			flags: flags,
+			verb:  rune(format[i]),
+			arg:   arg,
+		})
+		arg++
+	}
+
+	return directives, true
+}
@@ -1,13 +1,17 @@
-module github.com/ghostiam/protogetter
+module github.com/ghostiam/protogetter/v2
 
 go 1.22.0
 
 require (
+	github.com/fsnotify/fsnotify v1.8.0
 	github.com/gobwas/glob v0.2.3
-	golang.org/x/tools v0.25.0
+	github.com/golangci/plugin-module-register v0.1.1
+	golang.org/x/tools v0.28.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	golang.org/x/mod v0.21.0 // indirect
-	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
 )
@@ -0,0 +1,166 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const hoistMsgFormat = ruleLoopInvariant + ": proto getter chain %s is evaluated on each iteration of the loop, extract it to a local variable before the loop"
+
+func NewHoistAnalyzer(cfg *Config) *analysis.Analyzer {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	return &analysis.Analyzer{
+		Name:  "protohoist",
+		Doc:   "Reports loop-invariant proto getter chains evaluated on each iteration of loops",
+		Flags: flags(cfg),
+		Run: func(pass *analysis.Pass) (any, error) {
+			err := RunHoist(pass, cfg)
+			return nil, err
+		},
+	}
+}
+
+func RunHoist(pass *analysis.Pass, cfg *Config) error {
+	files, err := filterFiles(pass, cfg)
+	if err != nil {
+		return err
+	}
+
+	reported := make(map[token.Pos]struct{})
+
+	ins := inspector.New(files)
+	ins.Preorder([]ast.Node{(*ast.ForStmt)(nil), (*ast.RangeStmt)(nil)}, func(node ast.Node) {
+		var body *ast.BlockStmt
+		switch x := node.(type) {
+		case *ast.ForStmt:
+			body = x.Body
+		case *ast.RangeStmt:
+			body = x.Body
+		}
+
+		changed := changedInLoop(pass.TypesInfo, node)
+
+		ast.Inspect(body, func(n ast.Node) bool {
+			expr, ok := n.(ast.Expr)
+			if !ok {
+				return true
+			}
+
+			root, levels, ok := getterChain(cfg, pass.TypesInfo, expr)
+			if !ok || levels < 2 {
+				return true
+			}
+
+			obj := pass.TypesInfo.Uses[root]
+			if obj == nil || changed[obj] || (obj.Pos() >= node.Pos() && obj.Pos() < node.End()) {
+				// Skip the nested chains too, they are not loop-invariant either.
+				return false
+			}
+
+			if _, ok := reported[expr.Pos()]; !ok {
+				reported[expr.Pos()] = struct{}{}
+				reportNodef(pass, expr, hoistMsgFormat, formatNode(expr))
+			}
+
+			return false
+		})
+	})
+
+	return nil
+}
+
+// getterChain returns the root identifier and the number of levels of the chain of getter calls
+// and field reads on proto messages like m.GetA().GetB() or m.A.B.
+func getterChain(cfg *Config, info *types.Info, expr ast.Expr) (*ast.Ident, int, bool) {
+	levels := 0
+	for {
+		switch x := expr.(type) {
+		case *ast.Ident:
+			return x, levels, levels > 0
+
+		case *ast.CallExpr:
+			sel, ok := x.Fun.(*ast.SelectorExpr)
+			if !ok || len(x.Args) != 0 || !isGetterName(sel.Sel.Name) || !isProtoMessage(cfg, info, sel.X) {
+				return nil, 0, false
+			}
+			expr = sel.X
+
+		case *ast.SelectorExpr:
+			selection, ok := info.Selections[x]
+			if !ok || selection.Kind() != types.FieldVal || !isProtoMessage(cfg, info, x.X) {
+				return nil, 0, false
+			}
+			expr = x.X
+
+		default:
+			return nil, 0, false
+		}
+
+		levels++
+	}
+}
+
+// changedInLoop returns variables, which may be changed inside the loop: assigned, taken by address,
+// passed to functions or used as receivers of methods other than getters.
+func changedInLoop(info *types.Info, loop ast.Node) map[types.Object]bool {
+	changed := make(map[types.Object]bool)
+
+	markRoot := func(expr ast.Expr) {
+		for {
+			switch x := expr.(type) {
+			case *ast.Ident:
+				if obj := info.Uses[x]; obj != nil {
+					changed[obj] = true
+				}
+				return
+			case *ast.SelectorExpr:
+				expr = x.X
+			case *ast.IndexExpr:
+				expr = x.X
+			case *ast.StarExpr:
+				expr = x.X
+			case *ast.ParenExpr:
+				expr = x.X
+			case *ast.CallExpr:
+				expr = x.Fun
+			default:
+				return
+			}
+		}
+	}
+
+	ast.Inspect(loop, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range x.Lhs {
+				markRoot(lhs)
+			}
+		case *ast.IncDecStmt:
+			markRoot(x.X)
+		case *ast.UnaryExpr:
+			if x.Op == token.AND {
+				markRoot(x.X)
+			}
+		case *ast.CallExpr:
+			if sel, ok := x.Fun.(*ast.SelectorExpr); ok && !isGetterName(sel.Sel.Name) {
+				if _, ok := info.Selections[sel]; ok {
+					markRoot(sel.X)
+				}
+			}
+			for _, arg := range x.Args {
+				markRoot(arg)
+			}
+		}
+
+		return true
+	})
+
+	return changed
+}
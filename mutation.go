@@ -0,0 +1,255 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Mutations are not reported by default: they do not panic on nil messages the way reads do, but they bypass
+// the accessor methods, so the code does not compile after switching the messages to the opaque API.
+
+const (
+	addressMsgFormat         = ruleFieldAddress + ": avoid taking the address of proto field %s, use %s instead"
+	addressNoAccessMsgFormat = ruleFieldAddress + ": avoid taking the address of proto field %s, " +
+		"it bypasses the accessor methods and does not compile with the opaque API"
+
+	mutationMsgFormat         = ruleFieldMutation + ": avoid mutating proto field %s in place, use %s instead"
+	mutationNoAccessMsgFormat = ruleFieldMutation + ": avoid mutating proto field %s in place, " +
+		"it bypasses the accessor methods and does not compile with the opaque API"
+	sortMsgFormat = ruleFieldMutation + ": avoid sorting proto field %s in place, " +
+		"sort a copy like slices.Clone(%s) if the message must not be modified"
+)
+
+// sortFuncs are the functions sorting or reordering the slice passed as the first argument in place.
+var sortFuncs = map[string][]string{
+	"sort":   {"Slice", "SliceStable", "Strings", "Ints", "Float64s"},
+	"slices": {"Sort", "SortFunc", "SortStableFunc", "Reverse"},
+}
+
+// checkMutation reports mutations of proto fields which bypass the accessor methods.
+func checkMutation(pass *analysis.Pass, filter *posFilter, cfg *Config, node ast.Node) {
+	switch x := node.(type) {
+	case *ast.UnaryExpr:
+		if x.Op != token.AND {
+			return
+		}
+
+		sel, ok := protoField(cfg, pass.TypesInfo, x.X)
+		if !ok {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			Message: addressMessage(pass.TypesInfo, sel),
+		})
+
+	case *ast.IncDecStmt:
+		sel, ok := protoField(cfg, pass.TypesInfo, unstar(x.X))
+		if !ok {
+			return
+		}
+
+		op := token.ADD
+		if x.Tok == token.DEC {
+			op = token.SUB
+		}
+
+		reportMutation(pass, x, sel, op, &ast.BasicLit{Kind: token.INT, Value: "1"})
+
+	case *ast.AssignStmt:
+		// Compound assignments like m.Name += suffix.
+		if x.Tok < token.ADD_ASSIGN || x.Tok > token.AND_NOT_ASSIGN || len(x.Lhs) != 1 || len(x.Rhs) != 1 {
+			return
+		}
+
+		sel, ok := protoField(cfg, pass.TypesInfo, unstar(x.Lhs[0]))
+		if !ok {
+			return
+		}
+
+		// The operators are declared in the same order as the assignment operators.
+		op := token.ADD + (x.Tok - token.ADD_ASSIGN)
+		reportMutation(pass, x, sel, op, x.Rhs[0])
+
+	case *ast.CallExpr:
+		if isSortCall(pass.TypesInfo, x) {
+			checkSortedField(pass, filter, cfg, x)
+			return
+		}
+
+		// delete(m.Labels, k) works on the map returned by the getter with any API, the opaque one included.
+		if !isBuiltin(pass.TypesInfo, x.Fun, "delete") || len(x.Args) != 2 {
+			return
+		}
+
+		sel, ok := protoField(cfg, pass.TypesInfo, x.Args[0])
+		if !ok || !methodIsExists(pass.TypesInfo, sel.X, "Get"+sel.Sel.Name) {
+			return
+		}
+
+		// The field is reported here, so skip it in the getters check.
+		filter.AddPos(x.Args[0].Pos())
+
+		getter := "Get" + sel.Sel.Name
+		msg := fmt.Sprintf(mutationMsgFormat, formatNode(sel), fmt.Sprintf("delete(%s.%s(), %s)",
+			formatNode(sel.X), getter, formatNode(x.Args[1])))
+		pass.Report(analysis.Diagnostic{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message:   msg,
+					TextEdits: []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(getter + "()")}},
+				},
+			},
+		})
+	}
+}
+
+func isSortCall(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+
+	for _, name := range sortFuncs[fn.Pkg().Path()] {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSortedField reports sorting of a repeated field, read directly or with the getter,
+// which reorders the elements of the message as well.
+func checkSortedField(pass *analysis.Pass, filter *posFilter, cfg *Config, call *ast.CallExpr) {
+	arg := ast.Unparen(call.Args[0])
+
+	var field, getter string
+	if sel, ok := protoField(cfg, pass.TypesInfo, arg); ok {
+		field = formatNode(sel)
+		getter = formatNode(sel.X) + ".Get" + sel.Sel.Name + "()"
+
+		// The field is reported here, so skip it in the getters check.
+		filter.AddPos(arg.Pos())
+	} else if getterCall, ok := arg.(*ast.CallExpr); ok && len(getterCall.Args) == 0 {
+		sel, ok := getterCall.Fun.(*ast.SelectorExpr)
+		if !ok || !isGetterName(sel.Sel.Name) || !isProtoMessage(cfg, pass.TypesInfo, sel.X) {
+			return
+		}
+
+		field = formatNode(sel.X) + "." + strings.TrimPrefix(sel.Sel.Name, "Get")
+		getter = formatNode(getterCall)
+	} else {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf(sortMsgFormat, field, getter),
+	})
+}
+
+// reportMutation reports the in-place mutation of the field, with a fix replacing it with
+// m.SetField(m.GetField() op y) if the message has the accessor methods.
+func reportMutation(pass *analysis.Pass, stmt ast.Stmt, sel *ast.SelectorExpr, op token.Token, y ast.Expr) {
+	setter, getter := "Set"+sel.Sel.Name, "Get"+sel.Sel.Name
+	if !methodIsExists(pass.TypesInfo, sel.X, setter) || !methodIsExists(pass.TypesInfo, sel.X, getter) {
+		pass.Report(analysis.Diagnostic{
+			Pos:     stmt.Pos(),
+			End:     stmt.End(),
+			Message: fmt.Sprintf(mutationNoAccessMsgFormat, formatNode(sel)),
+		})
+		return
+	}
+
+	recv := formatNode(sel.X)
+	value := &ast.BinaryExpr{
+		X:  &ast.CallExpr{Fun: &ast.SelectorExpr{X: ast.NewIdent(recv), Sel: ast.NewIdent(getter)}},
+		Op: op,
+		Y:  parenthesize(y, op),
+	}
+	to := recv + "." + setter + "(" + formatNode(value) + ")"
+
+	diag := analysis.Diagnostic{
+		Pos:     stmt.Pos(),
+		End:     stmt.End(),
+		Message: fmt.Sprintf(mutationMsgFormat, formatNode(sel), to),
+	}
+
+	// The fix evaluates the receiver twice.
+	if !hasSideEffects(sel.X) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message:   diag.Message,
+				TextEdits: []analysis.TextEdit{{Pos: stmt.Pos(), End: stmt.End(), NewText: []byte(to)}},
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// parenthesize wraps the operand of the binary operation in parentheses if it has a lower precedence.
+func parenthesize(y ast.Expr, op token.Token) ast.Expr {
+	if b, ok := y.(*ast.BinaryExpr); ok && b.Op.Precedence() <= op.Precedence() {
+		return &ast.ParenExpr{X: y}
+	}
+
+	return y
+}
+
+// unstar returns the operand of the dereference, fields with explicit presence are pointers.
+func unstar(expr ast.Expr) ast.Expr {
+	if star, ok := ast.Unparen(expr).(*ast.StarExpr); ok {
+		return star.X
+	}
+
+	return expr
+}
+
+// addressMessage suggests the accessor methods of the field, if the message has them.
+func addressMessage(info *types.Info, sel *ast.SelectorExpr) string {
+	var accessors []string
+	for _, prefix := range []string{"Set", "Has"} {
+		if methodIsExists(info, sel.X, prefix+sel.Sel.Name) {
+			accessors = append(accessors, formatNode(sel.X)+"."+prefix+sel.Sel.Name)
+		}
+	}
+
+	if len(accessors) == 0 {
+		return fmt.Sprintf(addressNoAccessMsgFormat, formatNode(sel))
+	}
+
+	return fmt.Sprintf(addressMsgFormat, formatNode(sel), strings.Join(accessors, " and "))
+}
+
+// protoField returns the selector of the expression if it is a field of a proto message.
+func protoField(cfg *Config, info *types.Info, expr ast.Expr) (*ast.SelectorExpr, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+		return nil, false
+	}
+
+	return sel, isProtoMessage(cfg, info, sel.X)
+}
@@ -0,0 +1,251 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// nilFlow contains ranges of the code, where local variables holding proto messages are provably not nil:
+// after `if m == nil { return }` guards, inside `if m != nil { ... }` and after fresh `&pb.Msg{}` allocations.
+type nilFlow struct {
+	ranges map[types.Object][][2]token.Pos
+}
+
+func newNilFlow(info *types.Info, files []*ast.File) *nilFlow {
+	f := &nilFlow{
+		ranges: make(map[types.Object][][2]token.Pos),
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+
+			f.collect(info, fn.Body)
+		}
+	}
+
+	return f
+}
+
+// IsNonNil reports whether the variable is not nil at the given position.
+func (f *nilFlow) IsNonNil(obj types.Object, pos token.Pos) bool {
+	for _, r := range f.ranges[obj] {
+		if r[0] <= pos && pos < r[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nonNilIdent returns the identifier of the receiver of the expression, if the receiver is provably not nil.
+// Only single level selectors (m.Field) are considered, because nested messages may be nil anyway.
+func (f *nilFlow) nonNilIdent(info *types.Info, n ast.Node) (*ast.Ident, bool) {
+	if star, ok := n.(*ast.StarExpr); ok {
+		n = star.X
+	}
+
+	sel, ok := n.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	ident, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	obj := info.Uses[ident]
+	if obj == nil || !f.IsNonNil(obj, sel.Pos()) {
+		return nil, false
+	}
+
+	return ident, true
+}
+
+func (f *nilFlow) collect(info *types.Info, body *ast.BlockStmt) {
+	// Variables assigned only with fresh allocations, and variables reassigned with anything else.
+	allocated := make(map[types.Object]token.Pos)
+	reassigned := make(map[types.Object]bool)
+
+	assign := func(lhs, rhs ast.Expr) {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		obj := info.Defs[ident]
+		if obj == nil {
+			obj = info.Uses[ident]
+		}
+		if obj == nil || !isProtoMessagePointer(obj.Type()) {
+			return
+		}
+
+		if info.Defs[ident] != nil {
+			if rhs != nil && isAllocation(info, rhs) {
+				allocated[obj] = rhs.End()
+			}
+			return
+		}
+
+		if rhs != nil && isAllocation(info, rhs) {
+			return
+		}
+
+		reassigned[obj] = true
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if len(x.Lhs) != len(x.Rhs) {
+				for _, lhs := range x.Lhs {
+					assign(lhs, nil)
+				}
+				return true
+			}
+
+			for i, lhs := range x.Lhs {
+				assign(lhs, x.Rhs[i])
+			}
+
+		case *ast.ValueSpec:
+			for i, name := range x.Names {
+				var value ast.Expr
+				if i < len(x.Values) && len(x.Names) == len(x.Values) {
+					value = x.Values[i]
+				}
+				assign(name, value)
+			}
+
+		case *ast.UnaryExpr:
+			// The variable may be changed by the pointer.
+			if x.Op == token.AND {
+				if ident, ok := x.X.(*ast.Ident); ok && info.Uses[ident] != nil {
+					reassigned[info.Uses[ident]] = true
+				}
+			}
+		}
+
+		return true
+	})
+
+	for obj, pos := range allocated {
+		if !reassigned[obj] {
+			f.ranges[obj] = append(f.ranges[obj], [2]token.Pos{pos, body.End()})
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+
+		for _, stmt := range block.List {
+			ifStmt, ok := stmt.(*ast.IfStmt)
+			if !ok || ifStmt.Init != nil {
+				continue
+			}
+
+			// if m != nil { ... }
+			for _, ident := range comparedWithNil(ifStmt.Cond, token.NEQ, token.LAND) {
+				if obj := info.Uses[ident]; obj != nil && !reassigned[obj] {
+					f.ranges[obj] = append(f.ranges[obj], [2]token.Pos{ifStmt.Body.Pos(), ifStmt.Body.End()})
+				}
+			}
+
+			// if m == nil { return }
+			if ifStmt.Else != nil || !isTerminating(ifStmt.Body) {
+				continue
+			}
+
+			for _, ident := range comparedWithNil(ifStmt.Cond, token.EQL, token.LOR) {
+				if obj := info.Uses[ident]; obj != nil && !reassigned[obj] {
+					f.ranges[obj] = append(f.ranges[obj], [2]token.Pos{ifStmt.End(), block.End()})
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// comparedWithNil returns identifiers compared with nil using the op operator,
+// in the conditions joined with the join operator.
+func comparedWithNil(cond ast.Expr, op, join token.Token) []*ast.Ident {
+	switch x := ast.Unparen(cond).(type) {
+	case *ast.BinaryExpr:
+		if x.Op == join {
+			return append(comparedWithNil(x.X, op, join), comparedWithNil(x.Y, op, join)...)
+		}
+
+		if x.Op != op {
+			return nil
+		}
+
+		if ident, ok := ast.Unparen(x.X).(*ast.Ident); ok && isNilIdent(x.Y) {
+			return []*ast.Ident{ident}
+		}
+		if ident, ok := ast.Unparen(x.Y).(*ast.Ident); ok && isNilIdent(x.X) {
+			return []*ast.Ident{ident}
+		}
+	}
+
+	return nil
+}
+
+func isNilIdent(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// isTerminating reports whether the last statement of the block leaves it.
+func isTerminating(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+
+	switch x := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return x.Tok == token.CONTINUE || x.Tok == token.BREAK || x.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := x.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && ident.Name == "panic"
+	}
+
+	return false
+}
+
+// isAllocation reports whether the expression is &pb.Msg{...} or new(pb.Msg).
+func isAllocation(info *types.Info, expr ast.Expr) bool {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr:
+		_, ok := ast.Unparen(x.X).(*ast.CompositeLit)
+		return x.Op == token.AND && ok
+
+	case *ast.CallExpr:
+		return isBuiltin(info, x.Fun, "new")
+	}
+
+	return false
+}
+
+func isProtoMessagePointer(t types.Type) bool {
+	if _, ok := t.Underlying().(*types.Pointer); !ok {
+		return false
+	}
+
+	return typeHasMethod(t, "ProtoReflect") || typeHasMethod(t, "ProtoMessage")
+}
@@ -0,0 +1,266 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Messages generated with the hybrid API (see https://go.dev/blog/protobuf-opaque) have accessor methods
+// in addition to the exported fields, to migrate the code to the opaque API before the fields are hidden.
+// Direct writes and presence checks of their fields are reported, the open API messages have no
+// alternatives to them.
+
+const (
+	setterMsgFormat       = ruleAccessorMethod + ": avoid direct write to proto field %s, use %s instead"
+	setterMethodMsgFormat = ruleAccessorMethod + ": avoid direct write to proto field %s, use the %s method instead"
+	hasMsgFormat          = ruleAccessorMethod + ": avoid direct presence check of proto field %s, use %s instead"
+)
+
+const (
+	// opaqueHiddenFieldPrefix is the prefix of the unexported fields of the opaque API messages.
+	opaqueHiddenFieldPrefix = "xxx_hidden_"
+	// builderSuffix is the suffix of the builder types generated for the hybrid and opaque API messages.
+	builderSuffix = "_builder"
+)
+
+// API is the Go API generated for a proto message.
+type API int
+
+const (
+	// APIOpen messages have exported fields and getters.
+	APIOpen API = iota
+	// APIHybrid messages have exported fields and accessor methods (getters, setters, Has and Clear methods).
+	APIHybrid
+	// APIOpaque messages have accessor methods only, their fields are not exported.
+	APIOpaque
+)
+
+func (a API) String() string {
+	switch a {
+	case APIHybrid:
+		return "hybrid"
+	case APIOpaque:
+		return "opaque"
+	default:
+		return "open"
+	}
+}
+
+// MessageAPI returns the API of the proto message type or a pointer to it,
+// and false if the type is not a proto message.
+func MessageAPI(t types.Type) (API, bool) {
+	if !typeHasMethod(t, "ProtoReflect") && !typeHasMethod(t, "ProtoMessage") {
+		return APIOpen, false
+	}
+
+	st, ok := derefType(t).Underlying().(*types.Struct)
+	if !ok {
+		return APIOpen, true
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if strings.HasPrefix(st.Field(i).Name(), opaqueHiddenFieldPrefix) {
+			return APIOpaque, true
+		}
+	}
+
+	// Open API messages may have hand-written setters, but builders are generated for the hybrid API only.
+	obj := derefType(t).(*types.Named).Obj()
+	if obj.Pkg() != nil && obj.Pkg().Scope().Lookup(obj.Name()+builderSuffix) != nil {
+		return APIHybrid, true
+	}
+
+	return APIOpen, true
+}
+
+// checkHybridAccess reports direct writes and presence checks of fields of the hybrid API messages.
+func checkHybridAccess(pass *analysis.Pass, node ast.Node) {
+	switch x := node.(type) {
+	case *ast.AssignStmt:
+		if x.Tok != token.ASSIGN || len(x.Lhs) != 1 || len(x.Rhs) != 1 {
+			return
+		}
+
+		sel, ok := hybridField(pass.TypesInfo, x.Lhs[0])
+		if !ok {
+			return
+		}
+
+		reportSetter(pass, x, sel, ast.Unparen(x.Rhs[0]))
+
+	case *ast.BinaryExpr:
+		if x.Op != token.EQL && x.Op != token.NEQ {
+			return
+		}
+
+		operand := x.X
+		if isNil(pass.TypesInfo, operand) {
+			operand = x.Y
+		} else if !isNil(pass.TypesInfo, x.Y) {
+			return
+		}
+
+		sel, ok := hybridField(pass.TypesInfo, operand)
+		if !ok {
+			return
+		}
+
+		// Message fields are reported as reads, they are compared with the result of the getter instead.
+		if hasPointer, ok := getterResultHasPointer(pass.TypesInfo, sel.X, sel.Sel.Name); !ok || hasPointer {
+			return
+		}
+
+		has := "Has" + sel.Sel.Name
+		if !methodIsExists(pass.TypesInfo, sel.X, has) {
+			return
+		}
+
+		prefix := ""
+		if x.Op == token.EQL {
+			prefix = "!"
+		}
+
+		to := prefix + formatNode(sel.X) + "." + has + "()"
+		msg := fmt.Sprintf(hasMsgFormat, formatNode(sel), to)
+		pass.Report(analysis.Diagnostic{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{Pos: x.Pos(), End: sel.Pos(), NewText: []byte(prefix)},
+						{Pos: sel.Sel.Pos(), End: x.End(), NewText: []byte(has + "()")},
+					},
+				},
+			},
+		})
+	}
+}
+
+// hybridField returns the selector of the expression if it is a field of a hybrid API message.
+func hybridField(info *types.Info, expr ast.Expr) (*ast.SelectorExpr, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+		return nil, false
+	}
+
+	api, ok := MessageAPI(info.TypeOf(sel.X))
+	return sel, ok && api == APIHybrid
+}
+
+// reportSetter reports the assignment to the field of a hybrid API message, with a fix if the value
+// can be passed to the setter: nil is replaced with the Clear method, and proto.String(v) and other
+// pointer helpers are unwrapped.
+func reportSetter(pass *analysis.Pass, assign *ast.AssignStmt, sel *ast.SelectorExpr, rhs ast.Expr) {
+	setter := "Set" + sel.Sel.Name
+	method, ok := lookupMethod(pass.TypesInfo.TypeOf(sel.X), setter)
+	if !ok {
+		return
+	}
+
+	params := method.Type().(*types.Signature).Params()
+	if params.Len() != 1 {
+		return
+	}
+
+	clearMethod := "Clear" + sel.Sel.Name
+
+	var (
+		to    string
+		edits []analysis.TextEdit
+	)
+	switch {
+	case isNil(pass.TypesInfo, rhs) && methodIsExists(pass.TypesInfo, sel.X, clearMethod):
+		to = formatNode(sel.X) + "." + clearMethod + "()"
+		edits = []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: assign.End(), NewText: []byte(clearMethod + "()")}}
+
+	case types.AssignableTo(pass.TypesInfo.TypeOf(rhs), params.At(0).Type()):
+		to = formatNode(sel.X) + "." + setter + "(" + formatNode(rhs) + ")"
+		edits = []analysis.TextEdit{
+			{Pos: sel.Sel.Pos(), End: rhs.Pos(), NewText: []byte(setter + "(")},
+			{Pos: rhs.End(), End: rhs.End(), NewText: []byte(")")},
+		}
+
+	default:
+		arg, ok := protoPointerHelperArg(pass.TypesInfo, rhs)
+		if !ok || !types.AssignableTo(pass.TypesInfo.TypeOf(arg), params.At(0).Type()) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     assign.Pos(),
+				End:     assign.End(),
+				Message: fmt.Sprintf(setterMethodMsgFormat, formatNode(sel), setter),
+			})
+			return
+		}
+
+		// Keep the closing parenthesis of the helper call.
+		to = formatNode(sel.X) + "." + setter + "(" + formatNode(arg) + ")"
+		edits = []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: arg.Pos(), NewText: []byte(setter + "(")}}
+	}
+
+	msg := fmt.Sprintf(setterMsgFormat, formatNode(sel), to)
+	pass.Report(analysis.Diagnostic{
+		Pos:     assign.Pos(),
+		End:     assign.End(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message:   msg,
+				TextEdits: edits,
+			},
+		},
+	})
+}
+
+// protoPointerHelperArg returns the argument of the call of proto.String, proto.Int32 and other helpers
+// returning a pointer to the value.
+func protoPointerHelperArg(info *types.Info, expr ast.Expr) (ast.Expr, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || call.Ellipsis.IsValid() {
+		return nil, false
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil, false
+	}
+
+	switch fn.Pkg().Path() {
+	case "google.golang.org/protobuf/proto", "github.com/golang/protobuf/proto":
+	default:
+		return nil, false
+	}
+
+	switch fn.Name() {
+	case "Bool", "Int32", "Int64", "Float32", "Float64", "Uint32", "Uint64", "String":
+		return call.Args[0], true
+	}
+
+	return nil, false
+}
+
+func lookupMethod(t types.Type, name string) (*types.Func, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	fn, ok := obj.(*types.Func)
+	return fn, ok
+}
@@ -0,0 +1,122 @@
+// Package plugin registers protogetter as a golangci-lint module plugin.
+//
+// Build a custom golangci-lint binary with the plugin, see https://golangci-lint.run/plugins/module-plugins/:
+//
+//	# .custom-gcl.yml
+//	version: v1.62.0
+//	plugins:
+//	  - module: github.com/ghostiam/protogetter/v2
+//	    import: github.com/ghostiam/protogetter/v2/plugin
+//	    version: latest
+//
+// And enable it in the configuration:
+//
+//	# .golangci.yml
+//	linters:
+//	  enable:
+//	    - protogetter
+//	linters-settings:
+//	  custom:
+//	    protogetter:
+//	      type: module
+//	      settings:
+//	        skip-files: ["*_mock.go"]
+//	        report-mutations: true
+//	        analyzers: [protogetter, protofieldmask, protodirect]
+package plugin
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/ghostiam/protogetter/v2"
+)
+
+func init() {
+	register.Plugin("protogetter", New)
+}
+
+// Settings are the settings of the plugin in the golangci-lint configuration.
+// The keys are the names of the protogetter command line flags.
+type Settings struct {
+	SkipGeneratedBy         []string `json:"skip-generated-by"`
+	SkipFiles               []string `json:"skip-files"`
+	SkipAnyGenerated        bool     `json:"skip-any-generated"`
+	SkipNonNilReceivers     bool     `json:"skip-non-nil-receivers"`
+	ReportMutations         bool     `json:"report-mutations"`
+	ReplaceFirstArgInAppend bool     `json:"replace-first-arg-in-append"`
+
+	// Analyzers are the names of the analyzers to run, the default ones (protogetter.Analyzers) if empty.
+	// Opt-in analyzers like protodirect run only if they are listed.
+	Analyzers []string `json:"analyzers"`
+}
+
+// Plugin is the golangci-lint module plugin.
+type Plugin struct {
+	settings Settings
+}
+
+var _ register.LinterPlugin = (*Plugin)(nil)
+
+// New decodes the settings and returns the plugin. Unknown settings are rejected.
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plugin{settings: s}, nil
+}
+
+// BuildAnalyzers returns the enabled analyzers sharing the configuration of the settings.
+func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	cfg := &protogetter.Config{
+		SkipGeneratedBy:         p.settings.SkipGeneratedBy,
+		SkipFiles:               p.settings.SkipFiles,
+		SkipAnyGenerated:        p.settings.SkipAnyGenerated,
+		SkipNonNilReceivers:     p.settings.SkipNonNilReceivers,
+		ReportMutations:         p.settings.ReportMutations,
+		ReplaceFirstArgInAppend: p.settings.ReplaceFirstArgInAppend,
+	}
+
+	all := []*analysis.Analyzer{
+		protogetter.NewAnalyzer(cfg),
+		protogetter.NewFieldMaskAnalyzer(cfg),
+		protogetter.NewHoistAnalyzer(cfg),
+		protogetter.NewDirectAccessAnalyzer(cfg),
+	}
+
+	names := p.settings.Analyzers
+	if len(names) == 0 {
+		for _, a := range protogetter.Analyzers() {
+			names = append(names, a.Name)
+		}
+	}
+
+	var analyzers []*analysis.Analyzer
+	for _, name := range names {
+		i := slices.IndexFunc(all, func(a *analysis.Analyzer) bool { return a.Name == name })
+		if i < 0 {
+			known := make([]string, 0, len(all))
+			for _, a := range all {
+				known = append(known, a.Name)
+			}
+			return nil, fmt.Errorf("unknown analyzer %q, expected one of: %s", name, strings.Join(known, ", "))
+		}
+
+		if !slices.Contains(analyzers, all[i]) {
+			analyzers = append(analyzers, all[i])
+		}
+	}
+
+	return analyzers, nil
+}
+
+// GetLoadMode returns the load mode of the analyzers, which need type information.
+func (p *Plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -4,28 +4,28 @@ import (
 	"go/token"
 )
 
-type PosFilter struct {
+type posFilter struct {
 	positions       map[token.Pos]struct{}
 	alreadyReplaced map[string]map[int][2]int // map[filename][line][start, end]
 }
 
-func NewPosFilter() *PosFilter {
-	return &PosFilter{
+func newPosFilter() *posFilter {
+	return &posFilter{
 		positions:       make(map[token.Pos]struct{}),
 		alreadyReplaced: make(map[string]map[int][2]int),
 	}
 }
 
-func (f *PosFilter) IsFiltered(pos token.Pos) bool {
+func (f *posFilter) IsFiltered(pos token.Pos) bool {
 	_, ok := f.positions[pos]
 	return ok
 }
 
-func (f *PosFilter) AddPos(pos token.Pos) {
+func (f *posFilter) AddPos(pos token.Pos) {
 	f.positions[pos] = struct{}{}
 }
 
-func (f *PosFilter) IsAlreadyReplaced(fset *token.FileSet, pos, end token.Pos) bool {
+func (f *posFilter) IsAlreadyReplaced(fset *token.FileSet, pos, end token.Pos) bool {
 	filePos := fset.Position(pos)
 	fileEnd := fset.Position(end)
 
@@ -46,7 +46,7 @@ func (f *PosFilter) IsAlreadyReplaced(fset *token.FileSet, pos, end token.Pos) b
 	return false
 }
 
-func (f *PosFilter) AddAlreadyReplaced(fset *token.FileSet, pos, end token.Pos) {
+func (f *posFilter) AddAlreadyReplaced(fset *token.FileSet, pos, end token.Pos) {
 	filePos := fset.Position(pos)
 	fileEnd := fset.Position(end)
 
@@ -7,19 +7,27 @@ import (
 	"go/types"
 	"reflect"
 	"strings"
+
+	"golang.org/x/tools/go/analysis"
 )
 
 type processor struct {
 	info   *types.Info
-	filter *PosFilter
+	filter *posFilter
 	cfg    *Config
 
 	to   strings.Builder
 	from strings.Builder
 	err  error
+
+	// skipReason explains why the expression is left unchanged, it is collected only in the debug mode.
+	skipReason string
+
+	getters []*types.Func
+	edits   []analysis.TextEdit
 }
 
-func Process(info *types.Info, filter *PosFilter, n ast.Node, cfg *Config) (*Result, error) {
+func processNode(info *types.Info, filter *posFilter, n ast.Node, cfg *Config) (*processResult, error) {
 	p := &processor{
 		info:   info,
 		filter: filter,
@@ -29,15 +37,17 @@ func Process(info *types.Info, filter *PosFilter, n ast.Node, cfg *Config) (*Res
 	return p.process(n)
 }
 
-func (c *processor) process(n ast.Node) (*Result, error) {
+func (c *processor) process(n ast.Node) (*processResult, error) {
 	switch x := n.(type) {
 	case *ast.AssignStmt:
-		// Skip any assignment to the field.
+		// Skip any assignment to the field. Only the assigned expressions are skipped,
+		// reads on the right-hand side and in index expressions are checked as usual.
 		for _, s := range x.Lhs {
+			s = ast.Unparen(s)
 			c.filter.AddPos(s.Pos())
 
 			if se, ok := s.(*ast.StarExpr); ok {
-				c.filter.AddPos(se.X.Pos())
+				c.filter.AddPos(ast.Unparen(se.X).Pos())
 			}
 		}
 
@@ -45,6 +55,10 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 		// Skip any increment/decrement to the field.
 		c.filter.AddPos(x.X.Pos())
 
+		if se, ok := x.X.(*ast.StarExpr); ok {
+			c.filter.AddPos(se.X.Pos())
+		}
+
 	case *ast.UnaryExpr:
 		if x.Op == token.AND {
 			// Skip all expressions when the field is used as a pointer.
@@ -63,19 +77,22 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 
 		f, ok := x.Fun.(*ast.SelectorExpr)
 		if !ok {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
-		if !isProtoMessage(c.info, f.X) {
-			return &Result{}, nil
+		if !isProtoMessage(c.cfg, c.info, f.X) {
+			return &processResult{}, nil
 		}
 
 		c.processInner(x)
 
 	case *ast.SelectorExpr:
-		if !isProtoMessage(c.info, x.X) {
+		if !isProtoMessage(c.cfg, c.info, x.X) && !isPromotedProtoField(c.info, x) {
 			// If the selector is not on a proto message, skip it.
-			return &Result{}, nil
+			if c.cfg.Debug {
+				return &processResult{skipReason: notProtoMessageReason(c.info, x)}, nil
+			}
+			return &processResult{}, nil
 		}
 
 		c.processInner(x)
@@ -83,11 +100,11 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 	case *ast.StarExpr:
 		f, ok := x.X.(*ast.SelectorExpr)
 		if !ok {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
-		if !isProtoMessage(c.info, f.X) {
-			return &Result{}, nil
+		if !isProtoMessage(c.cfg, c.info, f.X) {
+			return &processResult{}, nil
 		}
 
 		// proto2 generates fields as pointers. Hence, the indirection
@@ -95,12 +112,13 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 		// The `*` is retained in `c.from`, but excluded from the fix
 		// present in the `c.to`.
 		c.writeFrom("*")
+		c.edits = append(c.edits, analysis.TextEdit{Pos: x.Star, End: x.X.Pos()})
 		c.processInner(x.X)
 
 	case *ast.BinaryExpr:
 		// Check if the expression is a comparison.
 		if x.Op != token.EQL && x.Op != token.NEQ {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
 		// Check if one of the operands is nil.
@@ -112,7 +130,7 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 		yIsNil := yOk && yIdent.Name == "nil"
 
 		if !xIsNil && !yIsNil {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
 		// Extract the non-nil operand for further checks
@@ -126,20 +144,20 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 
 		se, ok := expr.(*ast.SelectorExpr)
 		if !ok {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
-		if !isProtoMessage(c.info, se.X) {
-			return &Result{}, nil
+		if !isProtoMessage(c.cfg, c.info, se.X) {
+			return &processResult{}, nil
 		}
 
 		// Check if the Getter function of the protobuf message returns a pointer.
 		hasPointer, ok := getterResultHasPointer(c.info, se.X, se.Sel.Name)
 		if !ok || hasPointer {
-			return &Result{}, nil
+			return &processResult{}, nil
 		}
 
-		c.filter.AddPos(x.X.Pos())
+		c.filter.AddPos(expr.Pos())
 
 	default:
 		return nil, fmt.Errorf("not implemented for type: %s (%s)", reflect.TypeOf(x), formatNode(n))
@@ -149,9 +167,12 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 		return nil, c.err
 	}
 
-	return &Result{
-		From: c.from.String(),
-		To:   c.to.String(),
+	return &processResult{
+		From:       c.from.String(),
+		To:         c.to.String(),
+		skipReason: c.skipReason,
+		getters:    c.getters,
+		edits:      c.edits,
 	}, nil
 }
 
@@ -177,12 +198,22 @@ func (c *processor) processInner(expr ast.Expr) {
 		c.write(".")
 
 		// If getter exists, use it.
-		if methodIsExists(c.info, x.X, "Get"+x.Sel.Name) {
+		if methodIsExists(c.info, x.X, "Get"+x.Sel.Name) || isPromotedProtoField(c.info, x) {
 			c.writeFrom(x.Sel.Name)
 			c.writeTo("Get" + x.Sel.Name + "()")
+			c.addGetter(x.X, "Get"+x.Sel.Name)
+			c.edits = append(c.edits, analysis.TextEdit{
+				Pos:     x.Sel.Pos(),
+				End:     x.Sel.End(),
+				NewText: []byte("Get" + x.Sel.Name + "()"),
+			})
 			return
 		}
 
+		if c.cfg.Debug && hasProtobufTag(c.info, x) && isProtoMessage(c.cfg, c.info, x.X) {
+			c.skipReason = fmt.Sprintf("skip %s: %s has no getter Get%s", formatNode(x), c.info.TypeOf(x.X), x.Sel.Name)
+		}
+
 		// If the selector is not a proto-message or the method has already been called, we leave it unchanged.
 		// This approach is significantly more efficient than verifying the presence of methods in all cases.
 		c.write(x.Sel.Name)
@@ -227,6 +258,19 @@ func (c *processor) processInner(expr ast.Expr) {
 	}
 }
 
+// addGetter remembers the declaration of the suggested getter.
+func (c *processor) addGetter(x ast.Expr, name string) {
+	t := c.info.TypeOf(x)
+	if t == nil {
+		return
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	if getter, ok := obj.(*types.Func); ok {
+		c.getters = append(c.getters, getter)
+	}
+}
+
 func (c *processor) write(s string) {
 	c.writeTo(s)
 	c.writeFrom(s)
@@ -240,18 +284,27 @@ func (c *processor) writeFrom(s string) {
 	c.from.WriteString(s)
 }
 
-// Result contains source code (from) and suggested change (to)
-type Result struct {
+// processResult contains source code (from) and suggested change (to)
+type processResult struct {
 	From string
 	To   string
+
+	skipReason string
+	// getters are the declarations of the getters used in To.
+	getters []*types.Func
+	// edits are the minimal non-overlapping edits which turn From into To, leaving the rest of the expression
+	// (including its formatting and comments) untouched.
+	edits []analysis.TextEdit
 }
 
-func (r *Result) Skipped() bool {
+func (r *processResult) Skipped() bool {
 	// If from and to are the same, skip it.
 	return r.From == r.To
 }
 
-func isProtoMessage(info *types.Info, expr ast.Expr) bool {
+// isProtoMessage reports whether the type of the expression is a proto message with nil-safe getters,
+// or a message recognized by one of the configured detectors. The config may be nil.
+func isProtoMessage(cfg *Config, info *types.Info, expr ast.Expr) bool {
 	// First, we are checking for the presence of the ProtoReflect method which is currently being generated
 	// and corresponds to v2 version.
 	// https://pkg.go.dev/google.golang.org/protobuf@v1.31.0/proto#Message
@@ -270,11 +323,13 @@ func isProtoMessage(info *types.Info, expr ast.Expr) bool {
 	if ok {
 		// Since there is a protoc-gen-gogo generator that implements the proto.Message interface, but may not generate
 		// getters or generate from without checking for nil, so even if getters exist, we skip them.
+		// Note that protoc-gen-go-vtproto generates MarshalToSizedBufferVT instead, and its messages are
+		// regular protoc-gen-go messages with nil-safe getters.
 		const protocGenGoGoMethod = "MarshalToSizedBuffer"
 		return !methodIsExists(info, expr, protocGenGoGoMethod)
 	}
 
-	return false
+	return isDetectedMessage(cfg, info, expr)
 }
 
 func typesNamed(info *types.Info, x ast.Expr) (*types.Named, bool) {
@@ -315,6 +370,61 @@ func methodIsExists(info *types.Info, x ast.Expr, name string) bool {
 	return false
 }
 
+// isPromotedProtoField reports whether the selector reads a field promoted from a proto message embedded
+// into another struct (for example, structs wrapping messages for grpc-gateway runtime marshaling),
+// and the getter of this field is promoted as well.
+func isPromotedProtoField(info *types.Info, x *ast.SelectorExpr) bool {
+	if info == nil {
+		return false
+	}
+
+	selection, ok := info.Selections[x]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) < 2 {
+		return false
+	}
+
+	// Find the embedded struct which owns the field.
+	owner := selection.Recv()
+	for _, idx := range selection.Index()[:len(selection.Index())-1] {
+		st, ok := derefType(owner).Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+		owner = st.Field(idx).Type()
+	}
+
+	if !typeHasMethod(owner, "ProtoReflect") && !typeHasMethod(owner, "ProtoMessage") {
+		return false
+	}
+
+	getter, _, _ := types.LookupFieldOrMethod(selection.Recv(), true, nil, "Get"+x.Sel.Name)
+	_, ok = getter.(*types.Func)
+	return ok
+}
+
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+
+	return t
+}
+
+func typeHasMethod(t types.Type, name string) bool {
+	named, ok := derefType(t).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < named.NumMethods(); i++ {
+		if named.Method(i).Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getterResultHasPointer(info *types.Info, x ast.Expr, name string) (hasPointer, ok bool) {
 	named, ok := typesNamed(info, x)
 	if !ok {
@@ -7,7 +7,7 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
-	"log"
+	"go/types"
 	"path/filepath"
 	"strings"
 
@@ -16,7 +16,15 @@ import (
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-const msgFormat = "avoid direct access to proto field %s, use %s instead"
+const (
+	msgFormat         = ruleDirectRead + ": avoid direct access to proto field %s, use %s instead"
+	severityMsgFormat = " (%s severity: %s)"
+
+	nilReturnReasonFormat = "%s may return nil"
+	nonNilReasonFormat    = "%s is not nil"
+
+	getterDeclFormat = "%s is declared here"
+)
 
 func NewAnalyzer(cfg *Config) *analysis.Analyzer {
 	if cfg == nil {
@@ -31,6 +39,7 @@ func NewAnalyzer(cfg *Config) *analysis.Analyzer {
 			err := Run(pass, cfg)
 			return nil, err
 		},
+		FactTypes: []analysis.Fact{new(NilReturnFact)},
 	}
 }
 
@@ -49,7 +58,12 @@ func flags(opts *Config) flag.FlagSet {
 		}
 		return nil
 	})
-	fs.BoolVar(&opts.SkipAnyGenerated, "skip-any-generated", false, "skip any generated files")
+	fs.BoolVar(&opts.SkipAnyGenerated, "skip-any-generated", opts.SkipAnyGenerated, "skip any generated files")
+	fs.BoolVar(&opts.Debug, "debug", opts.Debug, "print reasons of skipped files and expressions to stderr")
+	fs.BoolVar(&opts.ReportMutations, "report-mutations", opts.ReportMutations,
+		"report mutations of proto fields which bypass the accessor methods, such as taking addresses of fields")
+	fs.BoolVar(&opts.SkipNonNilReceivers, "skip-non-nil-receivers", opts.SkipNonNilReceivers,
+		"skip direct access on receivers which are provably not nil instead of reporting them with low severity")
 
 	return *fs
 }
@@ -59,12 +73,99 @@ type Config struct {
 	SkipFiles               []string
 	SkipAnyGenerated        bool
 	ReplaceFirstArgInAppend bool
+	SkipNonNilReceivers     bool
+	// ReportMutations enables reports of mutations which bypass the accessor methods and break under the opaque API.
+	ReportMutations bool
+	Debug           bool
+	// MessageDetectors recognize messages of in-house code generators in addition to proto messages.
+	MessageDetectors []MessageDetector
 }
 
+// Run reports direct reads of proto message fields in the files of the pass.
+//
+// Run is safe to call from multiple goroutines with the same configuration, as drivers like golangci-lint
+// analyze packages concurrently: all state is kept per pass and the configuration is only read.
+// The configuration must not be modified while the analysis is running.
 func Run(pass *analysis.Pass, cfg *Config) error {
-	skipGeneratedBy := make([]string, 0, len(cfg.SkipGeneratedBy)+3)
-	// Always skip files generated by protoc-gen-go, protoc-gen-go-grpc and protoc-gen-grpc-gateway.
-	skipGeneratedBy = append(skipGeneratedBy, "protoc-gen-go", "protoc-gen-go-grpc", "protoc-gen-grpc-gateway")
+	files, err := filterFiles(pass, cfg)
+	if err != nil {
+		return err
+	}
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.SelectorExpr)(nil),
+		(*ast.StarExpr)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.UnaryExpr)(nil),
+	}
+
+	exportNilReturnFacts(pass, files)
+
+	ins := inspector.New(files)
+
+	filter := newPosFilter()
+	flow := newNilFlow(pass.TypesInfo, files)
+
+	ins.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(node ast.Node) {
+		checkVTPool(pass, filter, cfg, node.(*ast.FuncDecl))
+	})
+
+	ins.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.BinaryExpr)(nil)}, func(node ast.Node) {
+		checkHybridAccess(pass, node)
+	})
+
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		checkStructCopy(pass, cfg, node.(*ast.CallExpr))
+		checkStructFormat(pass, cfg, node.(*ast.CallExpr))
+	})
+
+	ins.Preorder([]ast.Node{(*ast.Ident)(nil)}, func(node ast.Node) {
+		checkEnumMap(pass, node.(*ast.Ident))
+	})
+
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil), (*ast.IndexExpr)(nil)}, func(node ast.Node) {
+		checkRowScan(pass, cfg, node)
+	})
+
+	if cfg.ReportMutations {
+		mutationNodes := []ast.Node{
+			(*ast.UnaryExpr)(nil),
+			(*ast.IncDecStmt)(nil),
+			(*ast.AssignStmt)(nil),
+			(*ast.CallExpr)(nil),
+		}
+		ins.Preorder(mutationNodes, func(node ast.Node) {
+			checkMutation(pass, filter, cfg, node)
+		})
+	}
+
+	ins.Preorder(nodeTypes, func(node ast.Node) {
+		report := analyse(pass, filter, flow, node, cfg)
+		if report == nil {
+			return
+		}
+		pass.Report(report.ToDiagReport())
+	})
+
+	return nil
+}
+
+// filterFiles returns the files of the pass, except generated and skipped by glob patterns ones.
+func filterFiles(pass *analysis.Pass, cfg *Config) ([]*ast.File, error) {
+	return FilterFiles(pass.Fset, pass.Files, cfg)
+}
+
+// FilterFiles returns the files which are analyzed with the configuration,
+// except generated and skipped by glob patterns ones.
+func FilterFiles(fset *token.FileSet, files []*ast.File, cfg *Config) ([]*ast.File, error) {
+	skipGeneratedBy := make([]string, 0, len(cfg.SkipGeneratedBy)+5)
+	// Always skip files generated by protoc-gen-go, protoc-gen-go-grpc, protoc-gen-grpc-gateway, protoc-gen-connect-go
+	// and protoc-gen-twirp.
+	skipGeneratedBy = append(skipGeneratedBy,
+		"protoc-gen-go", "protoc-gen-go-grpc", "protoc-gen-grpc-gateway", "protoc-gen-connect-go", "protoc-gen-twirp")
 	for _, s := range cfg.SkipGeneratedBy {
 		s = strings.TrimSpace(s)
 		if s == "" {
@@ -73,7 +174,9 @@ func Run(pass *analysis.Pass, cfg *Config) error {
 		skipGeneratedBy = append(skipGeneratedBy, s)
 	}
 
-	skipFilesGlobPatterns := make([]glob.Glob, 0, len(cfg.SkipFiles))
+	skipFilesGlobPatterns := make([]namedGlob, 0, len(cfg.SkipFiles)+1)
+	// Always skip files generated by protoc-gen-grpc-gateway, even if the generated header has been removed.
+	skipFilesGlobPatterns = append(skipFilesGlobPatterns, namedGlob{"*.pb.gw.go", glob.MustCompile("*.pb.gw.go")})
 	for _, s := range cfg.SkipFiles {
 		s = strings.TrimSpace(s)
 		if s == "" {
@@ -82,53 +185,34 @@ func Run(pass *analysis.Pass, cfg *Config) error {
 
 		compile, err := glob.Compile(s)
 		if err != nil {
-			return fmt.Errorf("invalid glob pattern: %w", err)
+			return nil, fmt.Errorf("invalid glob pattern: %w", err)
 		}
 
-		skipFilesGlobPatterns = append(skipFilesGlobPatterns, compile)
-	}
-
-	nodeTypes := []ast.Node{
-		(*ast.AssignStmt)(nil),
-		(*ast.BinaryExpr)(nil),
-		(*ast.CallExpr)(nil),
-		(*ast.SelectorExpr)(nil),
-		(*ast.StarExpr)(nil),
-		(*ast.IncDecStmt)(nil),
-		(*ast.UnaryExpr)(nil),
+		skipFilesGlobPatterns = append(skipFilesGlobPatterns, namedGlob{s, compile})
 	}
 
 	// Skip filtered files.
-	var files []*ast.File
-	for _, f := range pass.Files {
-		if skipGeneratedFile(f, skipGeneratedBy, cfg.SkipAnyGenerated) {
+	var filtered []*ast.File
+	for _, f := range files {
+		if header, ok := skipGeneratedFile(f, skipGeneratedBy, cfg.SkipAnyGenerated); ok {
+			debugf(fset, cfg, f.Package, "skip file: generated header %q matched", header)
 			continue
 		}
 
-		if skipFilesByGlob(pass.Fset.File(f.Pos()).Name(), skipFilesGlobPatterns) {
+		if pattern, ok := skipFilesByGlob(fset.File(f.Pos()).Name(), skipFilesGlobPatterns); ok {
+			debugf(fset, cfg, f.Package, "skip file: glob pattern %q matched", pattern)
 			continue
 		}
 
-		files = append(files, f)
+		filtered = append(filtered, f)
 
-		// ast.Print(pass.Fset, f)
+		// ast.Print(fset, f)
 	}
 
-	ins := inspector.New(files)
-
-	filter := NewPosFilter()
-	ins.Preorder(nodeTypes, func(node ast.Node) {
-		report := analyse(pass, filter, node, cfg)
-		if report == nil {
-			return
-		}
-		pass.Report(report.ToDiagReport())
-	})
-
-	return nil
+	return filtered, nil
 }
 
-func analyse(pass *analysis.Pass, filter *PosFilter, n ast.Node, cfg *Config) *Report {
+func analyse(pass *analysis.Pass, filter *posFilter, flow *nilFlow, n ast.Node, cfg *Config) *Report {
 	// fmt.Printf("\n>>> check: %s\n", formatNode(n))
 	// ast.Print(pass.Fset, n)
 	if filter.IsFiltered(n.Pos()) {
@@ -136,7 +220,7 @@ func analyse(pass *analysis.Pass, filter *PosFilter, n ast.Node, cfg *Config) *R
 		return nil
 	}
 
-	result, err := Process(pass.TypesInfo, filter, n, cfg)
+	result, err := processNode(pass.TypesInfo, filter, n, cfg)
 	if err != nil {
 		pass.Report(analysis.Diagnostic{
 			Pos:     n.Pos(),
@@ -153,6 +237,9 @@ func analyse(pass *analysis.Pass, filter *PosFilter, n ast.Node, cfg *Config) *R
 	}
 
 	if result.Skipped() {
+		if result.skipReason != "" {
+			debugf(pass.Fset, cfg, n.Pos(), "%s", result.skipReason)
+		}
 		return nil
 	}
 
@@ -160,75 +247,180 @@ func analyse(pass *analysis.Pass, filter *PosFilter, n ast.Node, cfg *Config) *R
 	if filter.IsAlreadyReplaced(pass.Fset, n.Pos(), n.End()) {
 		return nil
 	}
+	report := &Report{
+		node:     n,
+		result:   result,
+		severity: SeverityNormal,
+	}
+
+	if nilSource, ok := nilReturningCall(pass, n); ok {
+		report.severity = SeverityHigh
+		report.reason = fmt.Sprintf(nilReturnReasonFormat, nilSource)
+	} else if ident, ok := flow.nonNilIdent(pass.TypesInfo, n); ok {
+		if cfg.SkipNonNilReceivers {
+			debugf(pass.Fset, cfg, n.Pos(), "skip %s: %s is not nil", result.From, ident.Name)
+			return nil
+		}
+
+		report.severity = SeverityLow
+		report.reason = fmt.Sprintf(nonNilReasonFormat, ident.Name)
+	}
+
 	// Add the expression to the filter.
 	filter.AddAlreadyReplaced(pass.Fset, n.Pos(), n.End())
 
-	return &Report{
-		node:   n,
-		result: result,
+	return report
+}
+
+// Severity ranks reports by the risk of nil pointer dereference.
+type Severity int
+
+const (
+	SeverityLow Severity = iota - 1
+	SeverityNormal
+	SeverityHigh
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityHigh:
+		return "high"
+	default:
+		return "normal"
 	}
 }
 
+// Report is a direct read of a proto field found by Run.
 type Report struct {
-	node   ast.Node
-	result *Result
+	node     ast.Node
+	result   *processResult
+	severity Severity
+	reason   string
 }
 
+// ToDiagReport converts the report to the diagnostic with the suggested fix.
 func (r *Report) ToDiagReport() analysis.Diagnostic {
 	msg := fmt.Sprintf(msgFormat, r.result.From, r.result.To)
+	if r.severity != SeverityNormal {
+		msg += fmt.Sprintf(severityMsgFormat, r.severity, r.reason)
+	}
 
-	return analysis.Diagnostic{
+	var related []analysis.RelatedInformation
+	for _, getter := range r.result.getters {
+		related = append(related, analysis.RelatedInformation{
+			Pos:     getter.Pos(),
+			End:     getter.Pos() + token.Pos(len(getter.Name())),
+			Message: fmt.Sprintf(getterDeclFormat, getter.Name()),
+		})
+	}
+
+	diag := analysis.Diagnostic{
 		Pos:     r.node.Pos(),
 		End:     r.node.End(),
 		Message: msg,
-		SuggestedFixes: []analysis.SuggestedFix{
+		Related: related,
+	}
+
+	// Edits touch only the selectors, so calls and channel receives in the receiver are evaluated once as before.
+	edits := r.result.edits
+	if len(edits) == 0 {
+		// Rewriting the whole expression re-prints the receiver, which is unsafe if it has side effects.
+		if hasSideEffects(r.node) {
+			return diag
+		}
+
+		edits = []analysis.TextEdit{
 			{
-				Message: msg,
-				TextEdits: []analysis.TextEdit{
-					{
-						Pos:     r.node.Pos(),
-						End:     r.node.End(),
-						NewText: []byte(r.result.To),
-					},
-				},
+				Pos:     r.node.Pos(),
+				End:     r.node.End(),
+				NewText: []byte(r.result.To),
 			},
+		}
+	}
+
+	diag.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message:   msg,
+			TextEdits: edits,
 		},
 	}
+
+	return diag
+}
+
+// hasSideEffects reports whether evaluation of the node may call functions or receive from channels.
+func hasSideEffects(n ast.Node) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.CallExpr:
+			found = true
+		case *ast.UnaryExpr:
+			if x.Op == token.ARROW {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
 }
 
-func skipGeneratedFile(f *ast.File, prefixes []string, skipAny bool) bool {
+// reportNodef reports a diagnostic with the range of the whole node, so editors highlight the entire
+// expression (which may span several lines) instead of its first token.
+func reportNodef(pass *analysis.Pass, n ast.Node, format string, args ...any) {
+	pass.Report(analysis.Diagnostic{
+		Pos:     n.Pos(),
+		End:     n.End(),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// skipGeneratedFile reports whether the file is generated and should be skipped, and the matched header.
+func skipGeneratedFile(f *ast.File, prefixes []string, skipAny bool) (string, bool) {
 	if len(f.Comments) == 0 {
-		return false
+		return "", false
 	}
 	firstComment := f.Comments[0].Text()
 
 	if skipAny && ast.IsGenerated(f) {
-		return true
+		return "Code generated ... DO NOT EDIT.", true
 	}
 
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(firstComment, "Code generated by "+prefix) {
-			return true
+			return "Code generated by " + prefix, true
 		}
 	}
 
-	return false
+	return "", false
 }
 
-func skipFilesByGlob(filename string, patterns []glob.Glob) bool {
-	for _, pattern := range patterns {
-		if pattern.Match(filename) || pattern.Match(filepath.Base(filename)) {
-			return true
+// namedGlob is a compiled glob pattern with its source.
+type namedGlob struct {
+	pattern string
+	glob    glob.Glob
+}
+
+func skipFilesByGlob(filename string, patterns []namedGlob) (string, bool) {
+	for _, p := range patterns {
+		if p.glob.Match(filename) || p.glob.Match(filepath.Base(filename)) {
+			return p.pattern, true
 		}
 	}
 
-	return false
+	return "", false
 }
 
 func formatNode(node ast.Node) string {
 	buf := new(bytes.Buffer)
 	if err := format.Node(buf, token.NewFileSet(), node); err != nil {
-		log.Printf("Error formatting expression: %v", err)
+		// Do not log the error: the analyzer is run concurrently and must not depend on a global logger.
+		if expr, ok := node.(ast.Expr); ok {
+			return types.ExprString(expr)
+		}
 		return ""
 	}
 
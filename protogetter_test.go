@@ -1,11 +1,14 @@
 package protogetter_test
 
 import (
+	"go/types"
+	"strconv"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
 
-	"github.com/ghostiam/protogetter"
+	"github.com/ghostiam/protogetter/v2"
 )
 
 func Test(t *testing.T) {
@@ -16,3 +19,81 @@ func Test(t *testing.T) {
 
 	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./proto/...")
 }
+
+func TestConcurrentRun(t *testing.T) {
+	cfg := &protogetter.Config{}
+	analyzer := protogetter.NewAnalyzer(cfg)
+
+	testdata := analysistest.TestData()
+	for i := 0; i < 2; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			analysistest.Run(t, testdata, analyzer)
+		})
+	}
+}
+
+func TestFieldMask(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewFieldMaskAnalyzer(nil), "./fieldmask")
+}
+
+func TestSkipNonNilReceivers(t *testing.T) {
+	cfg := &protogetter.Config{
+		SkipNonNilReceivers: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./skipnonnil")
+}
+
+func TestReportMutations(t *testing.T) {
+	cfg := &protogetter.Config{
+		ReportMutations: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./mutation")
+}
+
+func TestMessageDetectors(t *testing.T) {
+	cfg := &protogetter.Config{
+		MessageDetectors: []protogetter.MessageDetector{
+			protogetter.MessageDetectorFunc(func(t *types.Named) bool {
+				obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, t.Obj().Pkg(), "IsRecord")
+				_, ok := obj.(*types.Func)
+				return ok
+			}),
+		},
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./detector")
+}
+
+func TestDirectAccess(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewDirectAccessAnalyzer(nil), "./directaccess")
+}
+
+func TestHoist(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewHoistAnalyzer(nil), "./hoist")
+}
+
+func TestExplainRule(t *testing.T) {
+	for _, r := range protogetter.Rules() {
+		doc, err := protogetter.ExplainRule(r.ID)
+		if err != nil {
+			t.Fatalf("explain %s: %v", r.ID, err)
+		}
+
+		if !strings.HasPrefix(doc, "# "+r.ID+": ") {
+			t.Errorf("explain %s: unexpected title: %q", r.ID, strings.SplitN(doc, "\n", 2)[0])
+		}
+	}
+
+	if _, err := protogetter.ExplainRule("PGL999"); err == nil {
+		t.Error("explain PGL999: expected error")
+	}
+}
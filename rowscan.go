@@ -0,0 +1,135 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const rowScanMsgFormat = ruleRowScan + ": avoid scanning rows into proto message %s with %s, the column mapping " +
+	"depends on field tags and reflection over the internal fields, scan into an intermediate row struct " +
+	"and convert it with getters and setters instead"
+
+// rowScanFuncs are the functions and methods ("Type.Method") of ORM and SQL libraries by package path,
+// with the index of the destination argument.
+var rowScanFuncs = map[string]map[string]int{
+	"gorm.io/gorm": {
+		"DB.First":         0,
+		"DB.Take":          0,
+		"DB.Last":          0,
+		"DB.Find":          0,
+		"DB.FindInBatches": 0,
+		"DB.FirstOrInit":   0,
+		"DB.FirstOrCreate": 0,
+		"DB.Scan":          0,
+		"DB.ScanRows":      1,
+	},
+	"github.com/jmoiron/sqlx": {
+		"Get":                     1,
+		"Select":                  1,
+		"StructScan":              1,
+		"GetContext":              2,
+		"SelectContext":           2,
+		"DB.Get":                  0,
+		"DB.Select":               0,
+		"DB.GetContext":           1,
+		"DB.SelectContext":        1,
+		"Tx.Get":                  0,
+		"Tx.Select":               0,
+		"Tx.GetContext":           1,
+		"Tx.SelectContext":        1,
+		"Stmt.Get":                0,
+		"Stmt.Select":             0,
+		"Stmt.GetContext":         1,
+		"Stmt.SelectContext":      1,
+		"NamedStmt.Get":           0,
+		"NamedStmt.Select":        0,
+		"NamedStmt.GetContext":    1,
+		"NamedStmt.SelectContext": 1,
+		"Row.StructScan":          0,
+		"Rows.StructScan":         0,
+	},
+}
+
+// rowToStructFuncs are the generic functions of pgx, which scan rows into the struct of the type argument.
+var rowToStructFuncs = []string{
+	"RowToStructByPos",
+	"RowToAddrOfStructByPos",
+	"RowToStructByName",
+	"RowToAddrOfStructByName",
+	"RowToStructByNameLax",
+	"RowToAddrOfStructByNameLax",
+}
+
+// checkRowScan reports ORM and SQL library calls scanning rows into proto messages,
+// and pgx row functions instantiated with proto messages.
+func checkRowScan(pass *analysis.Pass, cfg *Config, node ast.Node) {
+	switch x := node.(type) {
+	case *ast.CallExpr:
+		sel, ok := ast.Unparen(x.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return
+		}
+
+		i, ok := rowScanFuncs[fn.Pkg().Path()][funcKey(fn)]
+		if !ok || i >= len(x.Args) || !isProtoMessageValue(cfg, pass.TypesInfo.TypeOf(x.Args[i])) {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			Message: fmt.Sprintf(rowScanMsgFormat, formatNode(x.Args[i]), formatNode(sel)),
+		})
+
+	case *ast.IndexExpr:
+		sel, ok := ast.Unparen(x.X).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "github.com/jackc/pgx/v5" || !slices.Contains(rowToStructFuncs, fn.Name()) {
+			return
+		}
+
+		inst, ok := pass.TypesInfo.Instances[sel.Sel]
+		if !ok || inst.TypeArgs.Len() != 1 || !isProtoMessageValue(cfg, inst.TypeArgs.At(0)) {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			Message: fmt.Sprintf(rowScanMsgFormat, formatNode(x.Index), formatNode(sel)),
+		})
+	}
+}
+
+// funcKey returns the name of the function, prefixed with the name of the receiver type for methods.
+func funcKey(fn *types.Func) string {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return fn.Name()
+	}
+
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(recv).(*types.Named)
+	if !ok {
+		return fn.Name()
+	}
+
+	return named.Obj().Name() + "." + fn.Name()
+}
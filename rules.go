@@ -0,0 +1,70 @@
+package protogetter
+
+import (
+	"embed"
+	"fmt"
+)
+
+// Rule identifiers are stable and never reused, so they can be referenced in configs and nolint comments.
+const (
+	ruleDirectRead        = "PGL001"
+	ruleVTPoolRetained    = "PGL002"
+	ruleFieldMaskManual   = "PGL003"
+	ruleUnnecessaryGetter = "PGL004"
+	ruleLoopInvariant     = "PGL005"
+	ruleAccessorMethod    = "PGL006"
+	ruleFieldAddress      = "PGL007"
+	ruleFieldMutation     = "PGL008"
+	ruleStructCopy        = "PGL009"
+	ruleRowScan           = "PGL010"
+	ruleStructFormat      = "PGL011"
+	ruleEnumMap           = "PGL012"
+)
+
+//go:embed rules/*.md
+var rulesFS embed.FS
+
+// Rule describes a check reported by one of the analyzers.
+type Rule struct {
+	ID       string
+	Analyzer string
+	Summary  string
+}
+
+var rules = []Rule{
+	{ID: ruleDirectRead, Analyzer: "protogetter", Summary: "direct read of a proto field"},
+	{ID: ruleVTPoolRetained, Analyzer: "protogetter", Summary: "field retained from a message returned to the vtprotobuf pool"},
+	{ID: ruleFieldMaskManual, Analyzer: "protofieldmask", Summary: "manual manipulation of FieldMask paths"},
+	{ID: ruleUnnecessaryGetter, Analyzer: "protodirect", Summary: "unnecessary getter call on a message which is not nil"},
+	{ID: ruleLoopInvariant, Analyzer: "protohoist", Summary: "loop-invariant getter chain evaluated on each iteration"},
+	{ID: ruleAccessorMethod, Analyzer: "protogetter", Summary: "direct write or presence check of a field of a hybrid API message"},
+	{ID: ruleFieldAddress, Analyzer: "protogetter", Summary: "address of a proto field is taken (with -report-mutations)"},
+	{ID: ruleFieldMutation, Analyzer: "protogetter", Summary: "proto field is mutated in place (with -report-mutations)"},
+	{ID: ruleStructCopy, Analyzer: "protogetter", Summary: "proto message passed to a reflection-based struct copying library"},
+	{ID: ruleRowScan, Analyzer: "protogetter", Summary: "rows scanned directly into a proto message with an ORM or SQL library"},
+	{ID: ruleStructFormat, Analyzer: "protogetter", Summary: "proto message formatted as a Go struct with fmt verbs"},
+	{ID: ruleEnumMap, Analyzer: "protogetter", Summary: "use of the name and value maps generated for a proto enum"},
+}
+
+// Rules returns all rules reported by the analyzers.
+func Rules() []Rule {
+	return append([]Rule(nil), rules...)
+}
+
+// ExplainRule returns the rationale and examples of the rule.
+func ExplainRule(id string) (string, error) {
+	for _, r := range rules {
+		if r.ID != id {
+			continue
+		}
+
+		doc, err := rulesFS.ReadFile("rules/" + r.ID + ".md")
+		if err != nil {
+			return "", fmt.Errorf("read rule %s: %w", r.ID, err)
+		}
+
+		return string(doc), nil
+	}
+
+	return "", fmt.Errorf("unknown rule: %s", id)
+}
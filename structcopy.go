@@ -0,0 +1,79 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const structCopyMsgFormat = ruleStructCopy + ": avoid %s on proto message %s, it copies internal fields and bypasses " +
+	"the accessor methods, use proto.Merge, proto.Clone or getters and setters instead"
+
+// structCopyFuncs are the functions of struct-copying libraries by package path. They copy all exported
+// and sometimes unexported fields with reflection, including the internal state of the messages.
+var structCopyFuncs = map[string][]string{
+	"github.com/jinzhu/copier":            {"Copy", "CopyWithOption"},
+	"dario.cat/mergo":                     {"Merge", "MergeWithOverwrite", "Map", "MapWithOverwrite"},
+	"github.com/imdario/mergo":            {"Merge", "MergeWithOverwrite", "Map", "MapWithOverwrite"},
+	"github.com/mitchellh/mapstructure":   {"Decode", "WeakDecode", "DecodeMetadata", "WeakDecodeMetadata"},
+	"github.com/go-viper/mapstructure/v2": {"Decode", "WeakDecode", "DecodeMetadata", "WeakDecodeMetadata"},
+}
+
+// checkStructCopy reports calls of struct-copying libraries with proto messages as arguments.
+func checkStructCopy(pass *analysis.Pass, cfg *Config, call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || !slices.Contains(structCopyFuncs[fn.Pkg().Path()], fn.Name()) {
+		return
+	}
+
+	for _, arg := range call.Args {
+		if !isProtoMessageValue(cfg, pass.TypesInfo.TypeOf(arg)) {
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: fmt.Sprintf(structCopyMsgFormat, fn.Pkg().Name()+"."+fn.Name(), formatNode(arg)),
+		})
+		return
+	}
+}
+
+// isProtoMessageValue reports whether the type is a proto message, or a pointer, slice or array of them at any depth.
+func isProtoMessageValue(cfg *Config, t types.Type) bool {
+	for t != nil {
+		if typeHasMethod(t, "ProtoReflect") || typeHasMethod(t, "ProtoMessage") {
+			return true
+		}
+
+		if named, ok := types.Unalias(t).(*types.Named); ok && cfg != nil {
+			for _, d := range cfg.MessageDetectors {
+				if d.IsMessage(named) {
+					return true
+				}
+			}
+		}
+
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+// Package connect is a minimal stub of connectrpc.com/connect request and response wrappers.
+package connect
+
+type Request[T any] struct {
+	Msg *T
+}
+
+func NewRequest[T any](message *T) *Request[T] {
+	return &Request[T]{Msg: message}
+}
+
+func (r *Request[_]) Any() any {
+	return r.Msg
+}
+
+type Response[T any] struct {
+	Msg *T
+}
+
+func NewResponse[T any](message *T) *Response[T] {
+	return &Response[T]{Msg: message}
+}
+
+func (r *Response[_]) Any() any {
+	return r.Msg
+}
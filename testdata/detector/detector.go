@@ -0,0 +1,12 @@
+package detector
+
+import (
+	"github.com/ghostiam/protogetter/testdata/detector/records"
+)
+
+func testDetector(r *records.Record, p *records.Plain) {
+	_ = r.Name       // want `avoid direct access to proto field r\.Name, use r\.GetName\(\) instead`
+	_ = r.Inner.Name // want `avoid direct access to proto field r\.Inner\.Name, use r\.GetInner\(\)\.GetName\(\) instead`
+	_ = r.GetInner().GetName()
+	_ = p.Name
+}
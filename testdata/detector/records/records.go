@@ -0,0 +1,32 @@
+package records
+
+// Record is generated by an in-house code generator, which marks messages with the IsRecord method.
+type Record struct {
+	Name  string
+	Inner *Record
+}
+
+func (*Record) IsRecord() {}
+
+func (r *Record) GetName() string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+func (r *Record) GetInner() *Record {
+	if r == nil {
+		return nil
+	}
+	return r.Inner
+}
+
+// Plain is not a message, its fields are not reported.
+type Plain struct {
+	Name string
+}
+
+func (p *Plain) GetName() string {
+	return p.Name
+}
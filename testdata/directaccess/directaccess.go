@@ -0,0 +1,37 @@
+package directaccess
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid() {
+	t := &proto.Test{S: "test"}
+	_ = t.GetS()        // want `unnecessary getter call t\.GetS\(\) on the message which is not nil, use t\.S instead`
+	_ = t.GetEmbedded() // want `unnecessary getter call t\.GetEmbedded\(\) on the message which is not nil, use t\.Embedded instead`
+}
+
+func testInvalidGuard(t *proto.Test) {
+	if t == nil {
+		return
+	}
+
+	_ = t.GetI32()                  // want `unnecessary getter call t\.GetI32\(\) on the message which is not nil, use t\.I32 instead`
+	_ = (t). /* comment */ GetI64() // want `unnecessary getter call \(t\)\.GetI64\(\) on the message which is not nil, use t\.I64 instead`
+}
+
+func testValid(t *proto.Test, t2 *proto.TestProto2) {
+	_ = t.GetS()
+	_ = t.GetEmbedded().GetS()
+
+	if t != nil {
+		// Optional fields are pointers, the getters dereference them.
+		_ = t.GetOptBool()
+		// The receiver of the nested getter may be nil.
+		_ = t.GetEmbedded().GetS() // want `unnecessary getter call t\.GetEmbedded\(\) on the message which is not nil, use t\.Embedded instead`
+	}
+
+	if t2 != nil {
+		_ = t2.GetD()
+		_ = t2.GetB() // want `unnecessary getter call t2\.GetB\(\) on the message which is not nil, use t2\.B instead`
+	}
+}
@@ -0,0 +1,33 @@
+package fieldmask
+
+import (
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(mask, other *fieldmaskpb.FieldMask) {
+	mask.Paths = append(mask.Paths, "s")                 // want `avoid manual append to FieldMask paths mask\.Paths, use mask\.Append\(m, paths\.\.\.\) to validate paths against the message descriptor`
+	mask.Paths = append(mask.GetPaths(), "s", "d")       // want `avoid manual append to FieldMask paths mask\.GetPaths\(\), use mask\.Append\(m, paths\.\.\.\) to validate paths against the message descriptor`
+	mask.Paths = append(mask.Paths, other.GetPaths()...) // want `avoid manual merging of FieldMask paths append\(mask\.Paths, other\.GetPaths\(\)\.\.\.\), use fieldmaskpb\.Union\(mask, other\) instead`
+	mask.Paths = []string{"s"}                           // want `avoid manual assignment of FieldMask paths mask\.Paths, use fieldmaskpb\.New\(m, paths\.\.\.\) to validate paths against the message descriptor`
+
+	_ = &fieldmaskpb.FieldMask{Paths: []string{"s"}} // want `avoid manual construction of FieldMask paths, use fieldmaskpb\.New\(m, paths\.\.\.\) to validate paths against the message descriptor`
+	_ = fieldmaskpb.FieldMask{Paths: other.Paths}    // want `avoid manual construction of FieldMask paths, use fieldmaskpb\.New\(m, paths\.\.\.\) to validate paths against the message descriptor`
+}
+
+func testValid(mask, other *fieldmaskpb.FieldMask) error {
+	mask.Paths = nil
+	_ = fieldmaskpb.Union(mask, other)
+	_ = fieldmaskpb.Intersect(mask, other)
+	_ = &fieldmaskpb.FieldMask{}
+
+	paths := append([]string{}, "s")
+	paths = append(paths, "d")
+
+	if _, err := fieldmaskpb.New(&proto.Test{}, paths...); err != nil {
+		return err
+	}
+
+	return mask.Append(&proto.Test{}, "s")
+}
@@ -1,13 +1,27 @@
 module github.com/ghostiam/protogetter/testdata
 
-go 1.19
+go 1.22
 
 require (
-	github.com/golang/protobuf v1.5.3
-	golang.org/x/net v0.9.0
-	golang.org/x/sys v0.7.0
-	golang.org/x/text v0.9.0
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19
+	dario.cat/mergo v1.0.1
+	github.com/jackc/pgx/v5 v5.7.1
+	github.com/jinzhu/copier v0.4.0
+	github.com/jmoiron/sqlx v1.4.0
+	github.com/mitchellh/mapstructure v1.5.0
 	google.golang.org/grpc v1.57.0
-	google.golang.org/protobuf v1.31.0
+	google.golang.org/protobuf v1.36.6
+	gorm.io/gorm v1.25.12
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/crypto v0.27.0 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+	golang.org/x/text v0.18.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19 // indirect
 )
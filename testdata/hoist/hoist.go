@@ -0,0 +1,50 @@
+package hoist
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, items []string) {
+	for range items {
+		_ = t.GetEmbedded().GetEmbedded().GetS() // want `proto getter chain t\.GetEmbedded\(\)\.GetEmbedded\(\)\.GetS\(\) is evaluated on each iteration of the loop, extract it to a local variable before the loop`
+		_ = t.Embedded.S                         // want `proto getter chain t\.Embedded\.S is evaluated on each iteration of the loop, extract it to a local variable before the loop`
+	}
+
+	for i := 0; i < len(items); i++ {
+		for range items {
+			_ = t.GetEmbedded().GetS() // want `proto getter chain t\.GetEmbedded\(\)\.GetS\(\) is evaluated on each iteration of the loop, extract it to a local variable before the loop`
+		}
+	}
+}
+
+func testValid(t *proto.Test, tests []*proto.Test, items []string) {
+	for range items {
+		_ = t.GetS()
+	}
+
+	for _, v := range tests {
+		_ = v.GetEmbedded().GetS()
+	}
+
+	for range items {
+		_ = t.GetEmbedded().GetS()
+		t.Embedded = &proto.Embedded{}
+	}
+
+	for range items {
+		_ = t.GetEmbedded().GetS()
+		mutate(t)
+	}
+
+	for range items {
+		_ = t.GetEmbedded().GetS()
+		t.GetEmbedded().SetS("test")
+	}
+
+	embedded := t.GetEmbedded()
+	for range items {
+		_ = embedded.GetS()
+	}
+}
+
+func mutate(*proto.Test) {}
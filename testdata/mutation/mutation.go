@@ -0,0 +1,66 @@
+package mutation
+
+import (
+	"database/sql"
+	"slices"
+	"sort"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testAddress(t *proto.Test, h *proto.TestHybrid, rows *sql.Rows) {
+	_ = rows.Scan(&t.S, &t.I64) // want `avoid taking the address of proto field t\.S, it bypasses the accessor methods and does not compile with the opaque API` `avoid taking the address of proto field t\.I64, it bypasses the accessor methods and does not compile with the opaque API`
+
+	p := &h.S // want `avoid taking the address of proto field h\.S, use h\.SetS and h\.HasS instead`
+	_ = p
+
+	_ = &h.ImplicitS // want `avoid taking the address of proto field h\.ImplicitS, use h\.SetImplicitS instead`
+
+	// Addresses of messages are not fields.
+	_ = &proto.Test{}
+	s := ""
+	_ = &s
+}
+
+func testIncDec(t *proto.Test, h *proto.TestHybrid, hs []*proto.TestHybrid) {
+	t.I64++  // want `avoid mutating proto field t\.I64 in place, it bypasses the accessor methods and does not compile with the opaque API`
+	*h.I32++ // want `avoid mutating proto field h\.I32 in place, use h\.SetI32\(h\.GetI32\(\) \+ 1\) instead`
+	*h.I32-- // want `avoid mutating proto field h\.I32 in place, use h\.SetI32\(h\.GetI32\(\) - 1\) instead`
+
+	// Receivers with calls are not rewritten, they would be evaluated twice.
+	*hs[len(hs)-1].I32++ // want `avoid mutating proto field hs\[len\(hs\)-1\]\.I32 in place, use hs\[len\(hs\)-1\]\.SetI32\(hs\[len\(hs\)-1\]\.GetI32\(\) \+ 1\) instead`
+
+	i := 0
+	i++
+}
+
+func testCompoundAssign(t *proto.Test, h *proto.TestHybrid, s string, n int32) {
+	t.S += s               // want `avoid mutating proto field t\.S in place, it bypasses the accessor methods and does not compile with the opaque API`
+	h.ImplicitS += s + "!" // want `avoid mutating proto field h\.ImplicitS in place, use h\.SetImplicitS\(h\.GetImplicitS\(\) \+ \(s \+ "!"\)\) instead`
+	*h.I32 *= n + 1        // want `avoid mutating proto field h\.I32 in place, use h\.SetI32\(h\.GetI32\(\) \* \(n \+ 1\)\) instead`
+	*h.I32 <<= 2           // want `avoid mutating proto field h\.I32 in place, use h\.SetI32\(h\.GetI32\(\) << 2\) instead`
+	*h.I32 &^= n           // want `avoid mutating proto field h\.I32 in place, use h\.SetI32\(h\.GetI32\(\) &\^ n\) instead`
+
+	// Plain assignments are reported for hybrid messages only.
+	t.S = s
+}
+
+func testDelete(e *proto.TestEditions, h *proto.TestHybrid, k string) {
+	delete(e.Labels, k)     // want `avoid mutating proto field e\.Labels in place, use delete\(e\.GetLabels\(\), k\) instead`
+	delete(h.Labels, "key") // want `avoid mutating proto field h\.Labels in place, use delete\(h\.GetLabels\(\), "key"\) instead`
+	delete(e.GetLabels(), k)
+
+	m := map[string]string{}
+	delete(m, k)
+}
+
+func testSort(t *proto.Test, h *proto.TestHybrid) {
+	sort.Strings(h.RepeatedS)                                             // want `avoid sorting proto field h\.RepeatedS in place, sort a copy like slices\.Clone\(h\.GetRepeatedS\(\)\) if the message must not be modified`
+	slices.Sort(h.GetRepeatedS())                                         // want `avoid sorting proto field h\.RepeatedS in place, sort a copy like slices\.Clone\(h\.GetRepeatedS\(\)\) if the message must not be modified`
+	sort.Slice(t.RepeatedEmbeddeds, func(i, j int) bool { return false }) // want `avoid sorting proto field t\.RepeatedEmbeddeds in place, sort a copy like slices\.Clone\(t\.GetRepeatedEmbeddeds\(\)\) if the message must not be modified`
+	slices.Reverse(t.GetRepeatedEmbeddeds())                              // want `avoid sorting proto field t\.RepeatedEmbeddeds in place, sort a copy like slices\.Clone\(t\.GetRepeatedEmbeddeds\(\)\) if the message must not be modified`
+
+	s := slices.Clone(h.GetRepeatedS())
+	slices.Sort(s)
+	_ = slices.Contains(h.GetRepeatedS(), "")
+}
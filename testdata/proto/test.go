@@ -7,7 +7,7 @@ func (x *Embedded) CustomMethod() interface{} {
 type Other struct {
 }
 
-func (x *Other) MyMethod(certs *Test) *Embedded {
+func (x *Other) MyMethod(certs *Test) *Embedded { // want MyMethod:"mayReturnNil"
 	return nil
 }
 
@@ -0,0 +1,16 @@
+// Package proto is a reverse proxy.
+//
+// It translates gRPC into RESTful JSON APIs.
+// The generated header has been stripped on purpose: files with the .pb.gw.go suffix must be skipped anyway.
+
+package proto
+
+import (
+	"context"
+)
+
+func request_Testing_Call_0(ctx context.Context, client TestingClient, protoReq *Test) (*Test, error) {
+	msg, err := client.Call(ctx, protoReq)
+	_ = protoReq.S
+	return msg, err
+}
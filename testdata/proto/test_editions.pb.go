@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: test_editions.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TestEditions_Enum int32
+
+const (
+	TestEditions_ENUM_UNSPECIFIED TestEditions_Enum = 0
+	TestEditions_ENUM_VALUE       TestEditions_Enum = 1
+)
+
+// Enum value maps for TestEditions_Enum.
+var (
+	TestEditions_Enum_name = map[int32]string{
+		0: "ENUM_UNSPECIFIED",
+		1: "ENUM_VALUE",
+	}
+	TestEditions_Enum_value = map[string]int32{
+		"ENUM_UNSPECIFIED": 0,
+		"ENUM_VALUE":       1,
+	}
+)
+
+func (x TestEditions_Enum) Enum() *TestEditions_Enum {
+	p := new(TestEditions_Enum)
+	*p = x
+	return p
+}
+
+func (x TestEditions_Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TestEditions_Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_editions_proto_enumTypes[0].Descriptor()
+}
+
+func (TestEditions_Enum) Type() protoreflect.EnumType {
+	return &file_test_editions_proto_enumTypes[0]
+}
+
+func (x TestEditions_Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TestEditions_Enum.Descriptor instead.
+func (TestEditions_Enum) EnumDescriptor() ([]byte, []int) {
+	return file_test_editions_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type TestEditions struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	D                 *float64               `protobuf:"fixed64,1,opt,name=d" json:"d,omitempty"`
+	I32               *int32                 `protobuf:"varint,2,opt,name=i32,def=42" json:"i32,omitempty"`
+	T                 *bool                  `protobuf:"varint,3,opt,name=t" json:"t,omitempty"`
+	S                 *string                `protobuf:"bytes,4,opt,name=s" json:"s,omitempty"`
+	B                 []byte                 `protobuf:"bytes,5,opt,name=b" json:"b,omitempty"`
+	Embedded          *Embedded              `protobuf:"bytes,6,opt,name=embedded" json:"embedded,omitempty"`
+	RepeatedEmbeddeds []*Embedded            `protobuf:"bytes,7,rep,name=repeated_embeddeds,json=repeatedEmbeddeds" json:"repeated_embeddeds,omitempty"`
+	ImplicitS         string                 `protobuf:"bytes,8,opt,name=implicit_s,json=implicitS" json:"implicit_s,omitempty"`
+	ImplicitI64       int64                  `protobuf:"varint,9,opt,name=implicit_i64,json=implicitI64" json:"implicit_i64,omitempty"`
+	RequiredS         *string                `protobuf:"bytes,10,req,name=required_s,json=requiredS" json:"required_s,omitempty"`
+	Enum              *TestEditions_Enum     `protobuf:"varint,11,opt,name=enum,enum=TestEditions_Enum" json:"enum,omitempty"`
+	ImplicitEnum      TestEditions_Enum      `protobuf:"varint,12,opt,name=implicit_enum,json=implicitEnum,enum=TestEditions_Enum" json:"implicit_enum,omitempty"`
+	Labels            map[string]string      `protobuf:"bytes,13,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+// Default values for TestEditions fields.
+const (
+	Default_TestEditions_I32 = int32(42)
+)
+
+func (x *TestEditions) Reset() {
+	*x = TestEditions{}
+	mi := &file_test_editions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestEditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestEditions) ProtoMessage() {}
+
+func (x *TestEditions) ProtoReflect() protoreflect.Message {
+	mi := &file_test_editions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestEditions.ProtoReflect.Descriptor instead.
+func (*TestEditions) Descriptor() ([]byte, []int) {
+	return file_test_editions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TestEditions) GetD() float64 {
+	if x != nil && x.D != nil {
+		return *x.D
+	}
+	return 0
+}
+
+func (x *TestEditions) GetI32() int32 {
+	if x != nil && x.I32 != nil {
+		return *x.I32
+	}
+	return Default_TestEditions_I32
+}
+
+func (x *TestEditions) GetT() bool {
+	if x != nil && x.T != nil {
+		return *x.T
+	}
+	return false
+}
+
+func (x *TestEditions) GetS() string {
+	if x != nil && x.S != nil {
+		return *x.S
+	}
+	return ""
+}
+
+func (x *TestEditions) GetB() []byte {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+func (x *TestEditions) GetEmbedded() *Embedded {
+	if x != nil {
+		return x.Embedded
+	}
+	return nil
+}
+
+func (x *TestEditions) GetRepeatedEmbeddeds() []*Embedded {
+	if x != nil {
+		return x.RepeatedEmbeddeds
+	}
+	return nil
+}
+
+func (x *TestEditions) GetImplicitS() string {
+	if x != nil {
+		return x.ImplicitS
+	}
+	return ""
+}
+
+func (x *TestEditions) GetImplicitI64() int64 {
+	if x != nil {
+		return x.ImplicitI64
+	}
+	return 0
+}
+
+func (x *TestEditions) GetRequiredS() string {
+	if x != nil && x.RequiredS != nil {
+		return *x.RequiredS
+	}
+	return ""
+}
+
+func (x *TestEditions) GetEnum() TestEditions_Enum {
+	if x != nil && x.Enum != nil {
+		return *x.Enum
+	}
+	return TestEditions_ENUM_UNSPECIFIED
+}
+
+func (x *TestEditions) GetImplicitEnum() TestEditions_Enum {
+	if x != nil {
+		return x.ImplicitEnum
+	}
+	return TestEditions_ENUM_UNSPECIFIED
+}
+
+func (x *TestEditions) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+var File_test_editions_proto protoreflect.FileDescriptor
+
+const file_test_editions_proto_rawDesc = "" +
+	"\n" +
+	"\x13test_editions.proto\x1a\n" +
+	"test.proto\"\xb7\x04\n" +
+	"\fTestEditions\x12\f\n" +
+	"\x01d\x18\x01 \x01(\x01R\x01d\x12\x14\n" +
+	"\x03i32\x18\x02 \x01(\x05:\x0242R\x03i32\x12\f\n" +
+	"\x01t\x18\x03 \x01(\bR\x01t\x12\f\n" +
+	"\x01s\x18\x04 \x01(\tR\x01s\x12\f\n" +
+	"\x01b\x18\x05 \x01(\fR\x01b\x12%\n" +
+	"\bembedded\x18\x06 \x01(\v2\t.EmbeddedR\bembedded\x128\n" +
+	"\x12repeated_embeddeds\x18\a \x03(\v2\t.EmbeddedR\x11repeatedEmbeddeds\x12$\n" +
+	"\n" +
+	"implicit_s\x18\b \x01(\tB\x05\xaa\x01\x02\b\x02R\timplicitS\x12(\n" +
+	"\fimplicit_i64\x18\t \x01(\x03B\x05\xaa\x01\x02\b\x02R\vimplicitI64\x12$\n" +
+	"\n" +
+	"required_s\x18\n" +
+	" \x01(\tB\x05\xaa\x01\x02\b\x03R\trequiredS\x12&\n" +
+	"\x04enum\x18\v \x01(\x0e2\x12.TestEditions.EnumR\x04enum\x12>\n" +
+	"\rimplicit_enum\x18\f \x01(\x0e2\x12.TestEditions.EnumB\x05\xaa\x01\x02\b\x02R\fimplicitEnum\x121\n" +
+	"\x06labels\x18\r \x03(\v2\x19.TestEditions.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\",\n" +
+	"\x04Enum\x12\x14\n" +
+	"\x10ENUM_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ENUM_VALUE\x10\x01B0Z.github.com/ghostiam/protogetter/testdata/protob\beditionsp\xe8\a"
+
+var (
+	file_test_editions_proto_rawDescOnce sync.Once
+	file_test_editions_proto_rawDescData []byte
+)
+
+func file_test_editions_proto_rawDescGZIP() []byte {
+	file_test_editions_proto_rawDescOnce.Do(func() {
+		file_test_editions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_test_editions_proto_rawDesc), len(file_test_editions_proto_rawDesc)))
+	})
+	return file_test_editions_proto_rawDescData
+}
+
+var file_test_editions_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_test_editions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_test_editions_proto_goTypes = []any{
+	(TestEditions_Enum)(0), // 0: TestEditions.Enum
+	(*TestEditions)(nil),   // 1: TestEditions
+	nil,                    // 2: TestEditions.LabelsEntry
+	(*Embedded)(nil),       // 3: Embedded
+}
+var file_test_editions_proto_depIdxs = []int32{
+	3, // 0: TestEditions.embedded:type_name -> Embedded
+	3, // 1: TestEditions.repeated_embeddeds:type_name -> Embedded
+	0, // 2: TestEditions.enum:type_name -> TestEditions.Enum
+	0, // 3: TestEditions.implicit_enum:type_name -> TestEditions.Enum
+	2, // 4: TestEditions.labels:type_name -> TestEditions.LabelsEntry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_test_editions_proto_init() }
+func file_test_editions_proto_init() {
+	if File_test_editions_proto != nil {
+		return
+	}
+	file_test_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_editions_proto_rawDesc), len(file_test_editions_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_editions_proto_goTypes,
+		DependencyIndexes: file_test_editions_proto_depIdxs,
+		EnumInfos:         file_test_editions_proto_enumTypes,
+		MessageInfos:      file_test_editions_proto_msgTypes,
+	}.Build()
+	File_test_editions_proto = out.File
+	file_test_editions_proto_goTypes = nil
+	file_test_editions_proto_depIdxs = nil
+}
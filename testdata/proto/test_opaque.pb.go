@@ -0,0 +1,352 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: test_opaque.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	_ "google.golang.org/protobuf/types/gofeaturespb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TestHybrid struct {
+	state         protoimpl.MessageState `protogen:"hybrid.v1"`
+	S             *string                `protobuf:"bytes,1,opt,name=s" json:"s,omitempty"`
+	I32           *int32                 `protobuf:"varint,2,opt,name=i32" json:"i32,omitempty"`
+	ImplicitS     string                 `protobuf:"bytes,3,opt,name=implicit_s,json=implicitS" json:"implicit_s,omitempty"`
+	Embedded      *Embedded              `protobuf:"bytes,4,opt,name=embedded" json:"embedded,omitempty"`
+	RepeatedS     []string               `protobuf:"bytes,5,rep,name=repeated_s,json=repeatedS" json:"repeated_s,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestHybrid) Reset() {
+	*x = TestHybrid{}
+	mi := &file_test_opaque_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestHybrid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestHybrid) ProtoMessage() {}
+
+func (x *TestHybrid) ProtoReflect() protoreflect.Message {
+	mi := &file_test_opaque_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TestHybrid) GetS() string {
+	if x != nil && x.S != nil {
+		return *x.S
+	}
+	return ""
+}
+
+func (x *TestHybrid) GetI32() int32 {
+	if x != nil && x.I32 != nil {
+		return *x.I32
+	}
+	return 0
+}
+
+func (x *TestHybrid) GetImplicitS() string {
+	if x != nil {
+		return x.ImplicitS
+	}
+	return ""
+}
+
+func (x *TestHybrid) GetEmbedded() *Embedded {
+	if x != nil {
+		return x.Embedded
+	}
+	return nil
+}
+
+func (x *TestHybrid) GetRepeatedS() []string {
+	if x != nil {
+		return x.RepeatedS
+	}
+	return nil
+}
+
+func (x *TestHybrid) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *TestHybrid) SetS(v string) {
+	x.S = &v
+}
+
+func (x *TestHybrid) SetI32(v int32) {
+	x.I32 = &v
+}
+
+func (x *TestHybrid) SetImplicitS(v string) {
+	x.ImplicitS = v
+}
+
+func (x *TestHybrid) SetEmbedded(v *Embedded) {
+	x.Embedded = v
+}
+
+func (x *TestHybrid) SetRepeatedS(v []string) {
+	x.RepeatedS = v
+}
+
+func (x *TestHybrid) SetLabels(v map[string]string) {
+	x.Labels = v
+}
+
+func (x *TestHybrid) HasS() bool {
+	if x == nil {
+		return false
+	}
+	return x.S != nil
+}
+
+func (x *TestHybrid) HasI32() bool {
+	if x == nil {
+		return false
+	}
+	return x.I32 != nil
+}
+
+func (x *TestHybrid) HasEmbedded() bool {
+	if x == nil {
+		return false
+	}
+	return x.Embedded != nil
+}
+
+func (x *TestHybrid) ClearS() {
+	x.S = nil
+}
+
+func (x *TestHybrid) ClearI32() {
+	x.I32 = nil
+}
+
+func (x *TestHybrid) ClearEmbedded() {
+	x.Embedded = nil
+}
+
+type TestHybrid_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	S         *string
+	I32       *int32
+	ImplicitS string
+	Embedded  *Embedded
+	RepeatedS []string
+	Labels    map[string]string
+}
+
+func (b0 TestHybrid_builder) Build() *TestHybrid {
+	m0 := &TestHybrid{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.S = b.S
+	x.I32 = b.I32
+	x.ImplicitS = b.ImplicitS
+	x.Embedded = b.Embedded
+	x.RepeatedS = b.RepeatedS
+	x.Labels = b.Labels
+	return m0
+}
+
+type TestOpaque struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_S           *string                `protobuf:"bytes,1,opt,name=s"`
+	xxx_hidden_Embedded    *Embedded              `protobuf:"bytes,2,opt,name=embedded"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TestOpaque) Reset() {
+	*x = TestOpaque{}
+	mi := &file_test_opaque_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestOpaque) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestOpaque) ProtoMessage() {}
+
+func (x *TestOpaque) ProtoReflect() protoreflect.Message {
+	mi := &file_test_opaque_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TestOpaque) GetS() string {
+	if x != nil {
+		if x.xxx_hidden_S != nil {
+			return *x.xxx_hidden_S
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TestOpaque) GetEmbedded() *Embedded {
+	if x != nil {
+		return x.xxx_hidden_Embedded
+	}
+	return nil
+}
+
+func (x *TestOpaque) SetS(v string) {
+	x.xxx_hidden_S = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *TestOpaque) SetEmbedded(v *Embedded) {
+	x.xxx_hidden_Embedded = v
+}
+
+func (x *TestOpaque) HasS() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TestOpaque) HasEmbedded() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Embedded != nil
+}
+
+func (x *TestOpaque) ClearS() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_S = nil
+}
+
+func (x *TestOpaque) ClearEmbedded() {
+	x.xxx_hidden_Embedded = nil
+}
+
+type TestOpaque_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	S        *string
+	Embedded *Embedded
+}
+
+func (b0 TestOpaque_builder) Build() *TestOpaque {
+	m0 := &TestOpaque{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.S != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_S = b.S
+	}
+	x.xxx_hidden_Embedded = b.Embedded
+	return m0
+}
+
+var File_test_opaque_proto protoreflect.FileDescriptor
+
+const file_test_opaque_proto_rawDesc = "" +
+	"\n" +
+	"\x11test_opaque.proto\x1a!google/protobuf/go_features.proto\x1a\n" +
+	"test.proto\"\x8d\x02\n" +
+	"\n" +
+	"TestHybrid\x12\f\n" +
+	"\x01s\x18\x01 \x01(\tR\x01s\x12\x10\n" +
+	"\x03i32\x18\x02 \x01(\x05R\x03i32\x12$\n" +
+	"\n" +
+	"implicit_s\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x02R\timplicitS\x12%\n" +
+	"\bembedded\x18\x04 \x01(\v2\t.EmbeddedR\bembedded\x12\x1d\n" +
+	"\n" +
+	"repeated_s\x18\x05 \x03(\tR\trepeatedS\x12/\n" +
+	"\x06labels\x18\x06 \x03(\v2\x17.TestHybrid.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:\ab\x05\xd2>\x02\x10\x02\"J\n" +
+	"\n" +
+	"TestOpaque\x12\f\n" +
+	"\x01s\x18\x01 \x01(\tR\x01s\x12%\n" +
+	"\bembedded\x18\x02 \x01(\v2\t.EmbeddedR\bembedded:\ab\x05\xd2>\x02\x10\x03B0Z.github.com/ghostiam/protogetter/testdata/protob\beditionsp\xe8\a"
+
+var file_test_opaque_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_test_opaque_proto_goTypes = []any{
+	(*TestHybrid)(nil), // 0: TestHybrid
+	(*TestOpaque)(nil), // 1: TestOpaque
+	nil,                // 2: TestHybrid.LabelsEntry
+	(*Embedded)(nil),   // 3: Embedded
+}
+var file_test_opaque_proto_depIdxs = []int32{
+	3, // 0: TestHybrid.embedded:type_name -> Embedded
+	2, // 1: TestHybrid.labels:type_name -> TestHybrid.LabelsEntry
+	3, // 2: TestOpaque.embedded:type_name -> Embedded
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_test_opaque_proto_init() }
+func file_test_opaque_proto_init() {
+	if File_test_opaque_proto != nil {
+		return
+	}
+	file_test_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_opaque_proto_rawDesc), len(file_test_opaque_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_opaque_proto_goTypes,
+		DependencyIndexes: file_test_opaque_proto_depIdxs,
+		MessageInfos:      file_test_opaque_proto_msgTypes,
+	}.Build()
+	File_test_opaque_proto = out.File
+	file_test_opaque_proto_goTypes = nil
+	file_test_opaque_proto_depIdxs = nil
+}
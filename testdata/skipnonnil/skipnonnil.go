@@ -0,0 +1,19 @@
+package skipnonnil
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testSkipNonNil(t *proto.Test) string {
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead`
+
+	if t == nil {
+		return ""
+	}
+
+	e := &proto.Embedded{}
+	_ = e.S
+
+	_ = t.Embedded.S // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+	return t.S
+}
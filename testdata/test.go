@@ -42,6 +42,11 @@ func testInvalid(t *proto.Test) {
 	_ = t.GetRepeatedEmbeddeds()[0].GetEmbedded().S     // want `avoid direct access to proto field t\.GetRepeatedEmbeddeds\(\)\[0\].GetEmbedded\(\).S, use t\.GetRepeatedEmbeddeds\(\)\[0\].GetEmbedded\(\).GetS\(\) instead`
 	_ = t.RepeatedEmbeddeds[t.I64].Embedded.S           // want `avoid direct access to proto field t\.RepeatedEmbeddeds\[t.I64\]\.Embedded\.S, use t\.GetRepeatedEmbeddeds\(\)\[t\.GetI64\(\)\].GetEmbedded\(\).GetS\(\) instead`
 	_ = t.GetRepeatedEmbeddeds()[t.I64].GetEmbedded().S // want `avoid direct access to proto field t\.GetRepeatedEmbeddeds\(\)\[t\.I64\]\.GetEmbedded\(\)\.S, use t\.GetRepeatedEmbeddeds\(\)\[t\.GetI64\(\)\]\.GetEmbedded\(\).GetS\(\) instead`
+	_ = t.Embedded. /* comment */ S                     // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+
+	_ = t. // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+		Embedded.
+		S
 
 	var many []*proto.Test
 	manyIndex := 42
@@ -64,6 +69,9 @@ func testInvalid(t *proto.Test) {
 	ch := make(chan string)
 	ch <- t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead`
 
+	tests := make(chan *proto.Test, 1)
+	_ = (<-tests).Embedded // want `avoid direct access to proto field \(<-tests\)\.Embedded, use \(<-tests\)\.GetEmbedded\(\) instead`
+
 	for _, v := range t.RepeatedEmbeddeds { // want `avoid direct access to proto field t\.RepeatedEmbeddeds, use t\.GetRepeatedEmbeddeds\(\) instead`
 		_ = v
 	}
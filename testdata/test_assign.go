@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testAssignReads(t, other *proto.Test, m map[string]string) {
+	t.S = other.S                   // want `avoid direct access to proto field other\.S, use other\.GetS\(\) instead`
+	t.S, t.I64 = other.S, other.I64 // want `avoid direct access to proto field other\.S, use other\.GetS\(\) instead` `avoid direct access to proto field other\.I64, use other\.GetI64\(\) instead`
+	(t.S) = other.S                 // want `avoid direct access to proto field other\.S, use other\.GetS\(\) instead`
+	*(t.OptBool) = *other.OptBool   // want `avoid direct access to proto field \*other\.OptBool, use other\.GetOptBool\(\) instead`
+	t.S = t.S                       // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead`
+
+	// Indexes and map keys on the left-hand side are reads.
+	t.RepeatedEmbeddeds[other.I64] = other.Embedded // want `avoid direct access to proto field other\.I64, use other\.GetI64\(\) instead` `avoid direct access to proto field other\.Embedded, use other\.GetEmbedded\(\) instead`
+	m[other.S] = t.Embedded.S                       // want `avoid direct access to proto field other\.S, use other\.GetS\(\) instead` `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"context"
+
+	"github.com/ghostiam/protogetter/testdata/connect"
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+type connectHandler struct{}
+
+func (connectHandler) Call(_ context.Context, req *connect.Request[proto.Test]) (*connect.Response[proto.Test], error) {
+	_ = req.Msg.S          // want `avoid direct access to proto field req\.Msg\.S, use req\.Msg\.GetS\(\) instead`
+	_ = req.Msg.Embedded.S // want `avoid direct access to proto field req\.Msg\.Embedded\.S, use req\.Msg\.GetEmbedded\(\)\.GetS\(\) instead`
+
+	resp := connect.NewResponse(&proto.Test{S: req.Msg.GetS()})
+	_ = resp.Msg.Embedded // want `avoid direct access to proto field resp\.Msg\.Embedded, use resp\.Msg\.GetEmbedded\(\) instead`
+
+	return resp, nil
+}
+
+func testValidConnect(req *connect.Request[proto.Test]) {
+	_ = req.Msg
+	_ = req.Msg.GetS()
+	_ = req.Msg.GetEmbedded().GetS()
+	req.Msg.S = "test"
+}
@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testEditions(t *proto.TestEditions) {
+	// Explicit presence.
+	_ = *t.D                // want `avoid direct access to proto field \*t\.D, use t\.GetD\(\) instead`
+	_ = *t.I32              // want `avoid direct access to proto field \*t\.I32, use t\.GetI32\(\) instead`
+	_ = *t.T                // want `avoid direct access to proto field \*t\.T, use t\.GetT\(\) instead`
+	_ = *t.S                // want `avoid direct access to proto field \*t\.S, use t\.GetS\(\) instead`
+	_ = t.B                 // want `avoid direct access to proto field t\.B, use t\.GetB\(\) instead`
+	_ = *t.Enum             // want `avoid direct access to proto field \*t\.Enum, use t\.GetEnum\(\) instead`
+	_ = t.Embedded.S        // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+	_ = t.RepeatedEmbeddeds // want `avoid direct access to proto field t\.RepeatedEmbeddeds, use t\.GetRepeatedEmbeddeds\(\) instead`
+	_ = *t.RequiredS        // want `avoid direct access to proto field \*t\.RequiredS, use t\.GetRequiredS\(\) instead`
+
+	// Implicit presence.
+	_ = t.ImplicitS    // want `avoid direct access to proto field t\.ImplicitS, use t\.GetImplicitS\(\) instead`
+	_ = t.ImplicitI64  // want `avoid direct access to proto field t\.ImplicitI64, use t\.GetImplicitI64\(\) instead`
+	_ = t.ImplicitEnum // want `avoid direct access to proto field t\.ImplicitEnum, use t\.GetImplicitEnum\(\) instead`
+
+	// Presence checks of fields with explicit presence can not be replaced with getters,
+	// which return the default value of unset fields.
+	_ = t.S != nil && t.I32 == nil
+
+	// Message fields have explicit presence, the getter returns nil for unset fields.
+	_ = t.Embedded != nil // want `avoid direct access to proto field t\.Embedded, use t\.GetEmbedded\(\) instead`
+
+	// Writes are not reported.
+	t.S = nil
+	t.ImplicitS = ""
+	t.D = new(float64)
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testEnumMap(e proto.Test_OEnum, name string) (string, proto.Test_OEnum, bool) {
+	s := proto.Test_OEnum_name[int32(e)]  // want `avoid reading enum name map proto\.Test_OEnum_name, use the String method of proto\.Test_OEnum instead`
+	v, ok := proto.Test_OEnum_value[name] // want `avoid reading enum value map proto\.Test_OEnum_value, use the enum descriptor instead, like proto\.Test_OEnum\(0\)\.Descriptor\(\)\.Values\(\)\.ByName\(name\)`
+
+	for range proto.Test_OEnum_name { // want `avoid reading enum name map proto\.Test_OEnum_name`
+	}
+
+	return s, proto.Test_OEnum(v), ok
+}
+
+var testEnumMapOther_name = map[int32]string{0: "zero"}
+
+type testEnumMapOther int32
+
+func testEnumMapNotProto() string {
+	return testEnumMapOther_name[0]
+}
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	"errors"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func findTest(tests []*proto.Test) *proto.Test { // want findTest:"mayReturnNil"
+	for _, t := range tests {
+		if t.GetS() != "" {
+			return t
+		}
+	}
+	return nil
+}
+
+func findTestOrError(tests []*proto.Test) (*proto.Test, error) { // want findTestOrError:"mayReturnNil"
+	if len(tests) == 0 {
+		return nil, errors.New("empty")
+	}
+	return findTest(tests), nil
+}
+
+func newTest(s string) (*proto.Test, error) {
+	if s == "" {
+		return &proto.Test{}, errors.New("empty")
+	}
+	return &proto.Test{S: s}, nil
+}
+
+func testInvalidFacts(tests []*proto.Test) {
+	_ = findTest(tests).S // want `avoid direct access to proto field findTest\(tests\)\.S, use findTest\(tests\)\.GetS\(\) instead \(high severity: findTest may return nil\)`
+
+	other := proto.Other{}
+	_ = other.MyMethod(nil).Embedded // want `avoid direct access to proto field other\.MyMethod\(nil\)\.Embedded, use other\.MyMethod\(nil\)\.GetEmbedded\(\) instead \(high severity: other\.MyMethod may return nil\)`
+
+	t, _ := newTest("a")
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead$`
+}
@@ -0,0 +1,30 @@
+package testdata
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testFmtVerbs(t *proto.Test, list []*proto.Test, format string) {
+	fmt.Printf("test: %#v\n", t)                   // want `avoid formatting proto message t with %#v, it prints the internal fields of the message, use prototext\.Format\(t\) or the String method instead`
+	_ = fmt.Sprintf("%d: %-#10v", 1, t)            // want `avoid formatting proto message t with %-#v`
+	_ = fmt.Errorf("bad %#v: %w", t, os.ErrClosed) // want `avoid formatting proto message t with %#v`
+	fmt.Fprintf(os.Stderr, "%*d %#v", 3, 1, t)     // want `avoid formatting proto message t with %#v`
+	log.Printf(`%#v`, t)                           // want `avoid formatting proto message t with %#v`
+	log.Default().Printf("%#v", t.GetEmbedded())   // want `avoid formatting proto message t\.GetEmbedded\(\) with %#v`
+	fmt.Printf("\t%#v", t)                         // want `avoid formatting proto message t with %#v`
+
+	// Values are formatted as structs with any verb.
+	fmt.Printf("%+v %s", *t, *t) // want `avoid formatting proto message \*t with %\+v` `avoid formatting proto message \*t with %s`
+
+	// Formats which are not constants are skipped.
+	fmt.Printf(format, t)
+
+	// The String method is used.
+	fmt.Printf("%v %+v %s %d%%", t, t, t, 1)
+	fmt.Printf("%v", list)
+	fmt.Printf("%[1]v %#[1]v", t)
+}
@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+// gatewayBody wraps a proto message the same way as grpc-gateway runtime marshaling structs do.
+type gatewayBody struct {
+	*proto.Test
+	Extra string
+}
+
+type gatewayEnvelope struct {
+	gatewayBody
+}
+
+func testInvalidGateway(b *gatewayBody, e gatewayEnvelope) {
+	_ = b.S          // want `avoid direct access to proto field b\.S, use b\.GetS\(\) instead`
+	_ = b.Embedded.S // want `avoid direct access to proto field b\.Embedded\.S, use b\.GetEmbedded\(\)\.GetS\(\) instead`
+	_ = e.Embedded   // want `avoid direct access to proto field e\.Embedded, use e\.GetEmbedded\(\) instead`
+}
+
+func testValidGateway(b *gatewayBody) {
+	_ = b.Extra
+	_ = b.Test
+	_ = b.GetS()
+	_ = b.GetEmbedded().GetS()
+	b.S = "test"
+}
@@ -0,0 +1,47 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testNilFlowGuard(t *proto.Test) string {
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead$`
+
+	if t == nil {
+		return ""
+	}
+
+	_ = t.Embedded.S // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead$`
+	return t.S       // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead \(low severity: t is not nil\)`
+}
+
+func testNilFlowNotNil(t *proto.Test) {
+	if t != nil && t.Embedded != nil { // want `avoid direct access to proto field t\.Embedded, use t\.GetEmbedded\(\) instead$`
+		_ = t.S          // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead \(low severity: t is not nil\)`
+		_ = t.Embedded.S // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead$`
+	}
+
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead$`
+}
+
+func testNilFlowAllocation() {
+	t := &proto.Test{}
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead \(low severity: t is not nil\)`
+
+	e := new(proto.Embedded)
+	_ = e.S // want `avoid direct access to proto field e\.S, use e\.GetS\(\) instead \(low severity: e is not nil\)`
+}
+
+func testNilFlowReassigned(t *proto.Test, other *proto.Test) {
+	if t == nil {
+		return
+	}
+
+	r := &proto.Test{}
+	r = other
+
+	_ = t.S // want `avoid direct access to proto field t\.S, use t\.GetS\(\) instead$`
+	_ = r.S // want `avoid direct access to proto field r\.S, use r\.GetS\(\) instead$`
+
+	t = other
+}
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testHybrid(t *proto.TestHybrid, s string) {
+	_ = *t.S        // want `avoid direct access to proto field \*t\.S, use t\.GetS\(\) instead`
+	_ = t.ImplicitS // want `avoid direct access to proto field t\.ImplicitS, use t\.GetImplicitS\(\) instead`
+
+	t.S = protobuf.String("test")  // want `avoid direct write to proto field t\.S, use t\.SetS\("test"\) instead`
+	t.I32 = protobuf.Int32(42)     // want `avoid direct write to proto field t\.I32, use t\.SetI32\(42\) instead`
+	t.S = nil                      // want `avoid direct write to proto field t\.S, use t\.ClearS\(\) instead`
+	t.ImplicitS = s                // want `avoid direct write to proto field t\.ImplicitS, use t\.SetImplicitS\(s\) instead`
+	t.Embedded = &proto.Embedded{} // want `avoid direct write to proto field t\.Embedded, use t\.SetEmbedded\(&proto\.Embedded\{\}\) instead`
+	t.S = &s                       // want `avoid direct write to proto field t\.S, use the SetS method instead`
+
+	_ = t.S != nil   // want `avoid direct presence check of proto field t\.S, use t\.HasS\(\) instead`
+	_ = nil == t.I32 // want `avoid direct presence check of proto field t\.I32, use !t\.HasI32\(\) instead`
+
+	// Message fields are compared with the getter result.
+	_ = t.Embedded != nil // want `avoid direct access to proto field t\.Embedded, use t\.GetEmbedded\(\) instead`
+
+	// Compound assignments are not replaced with setters.
+	t.ImplicitS += s
+}
+
+func testOpen(t *proto.TestEditions) {
+	// Open API messages have no setters.
+	t.S = protobuf.String("test")
+	_ = t.S != nil
+}
+
+func testOpaque(t *proto.TestOpaque) {
+	_ = t.GetS()
+	t.SetS("test")
+	_ = t.HasS()
+}
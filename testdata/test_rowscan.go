@@ -0,0 +1,44 @@
+package testdata
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	"gorm.io/gorm"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+type testRowScanRow struct {
+	S   string `db:"s"`
+	I64 int64  `db:"i64"`
+}
+
+func testRowScanGorm(db *gorm.DB, t *proto.Test) {
+	var list []*proto.Test
+	db.First(t, 1)                       // want `avoid scanning rows into proto message t with db\.First, the column mapping depends on field tags and reflection over the internal fields, scan into an intermediate row struct and convert it with getters and setters instead`
+	db.Where("s = ?", "s").Find(&list)   // want `avoid scanning rows into proto message &list with db\.Where\("s = \?", "s"\)\.Find`
+	db.Raw("SELECT 1").Scan(t)           // want `avoid scanning rows into proto message t with db\.Raw\("SELECT 1"\)\.Scan`
+	db.Where("s = ?", t.GetS()).First(t) // want `avoid scanning rows into proto message t with db\.Where\("s = \?", t\.GetS\(\)\)\.First`
+
+	var row testRowScanRow
+	db.First(&row, 1)
+	db.Create(t)
+}
+
+func testRowScanSqlx(ctx context.Context, db *sqlx.DB, rows *sqlx.Rows, t *proto.Test) error {
+	var list []proto.Test
+	_ = db.Get(t, "SELECT s FROM t")                    // want `avoid scanning rows into proto message t with db\.Get`
+	_ = db.SelectContext(ctx, &list, "SELECT s FROM t") // want `avoid scanning rows into proto message &list with db\.SelectContext`
+	_ = sqlx.Get(db, t, "SELECT s FROM t")              // want `avoid scanning rows into proto message t with sqlx\.Get`
+	_ = rows.StructScan(t)                              // want `avoid scanning rows into proto message t with rows\.StructScan`
+
+	var row testRowScanRow
+	return db.Get(&row, "SELECT s FROM t", t.GetS())
+}
+
+func testRowScanPgx(rows pgx.Rows) ([]*proto.Test, error) {
+	_, _ = pgx.CollectRows(rows, pgx.RowToStructByName[testRowScanRow])
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[proto.Test]) // want `avoid scanning rows into proto message proto\.Test with pgx\.RowToAddrOfStructByName`
+}
@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	"dario.cat/mergo"
+	"github.com/jinzhu/copier"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+type testStructCopyDTO struct {
+	S   string
+	I64 int64
+}
+
+func testStructCopy(t *proto.Test, dto *testStructCopyDTO, values map[string]any) error {
+	var copied proto.Test
+	_ = copier.Copy(&copied, t)                                      // want `avoid copier\.Copy on proto message &copied, it copies internal fields and bypasses the accessor methods, use proto\.Merge, proto\.Clone or getters and setters instead`
+	_ = copier.CopyWithOption(dto, t, copier.Option{DeepCopy: true}) // want `avoid copier\.CopyWithOption on proto message t`
+	_ = mergo.Merge(t, dto)                                          // want `avoid mergo\.Merge on proto message t`
+	_ = mergo.MergeWithOverwrite(&copied, t)                         // want `avoid mergo\.MergeWithOverwrite on proto message &copied`
+	_ = mapstructure.Decode(values, t)                               // want `avoid mapstructure\.Decode on proto message t`
+
+	// Plain structs are fine.
+	var other testStructCopyDTO
+	_ = copier.Copy(&other, dto)
+	_ = mergo.Merge(&other, dto)
+	return mapstructure.Decode(values, &other)
+}
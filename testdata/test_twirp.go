@@ -0,0 +1,28 @@
+package testdata
+
+import (
+	"context"
+
+	"github.com/ghostiam/protogetter/testdata/proto/twirp"
+)
+
+type haberdasher struct{}
+
+var _ twirp.Haberdasher = haberdasher{}
+
+func (haberdasher) MakeHat(_ context.Context, size *twirp.Size) (*twirp.Hat, error) {
+	hat := &twirp.Hat{
+		Size:  size.Inches, // want `avoid direct access to proto field size\.Inches, use size\.GetInches\(\) instead`
+		Color: "red",
+	}
+
+	_ = hat.Brim.Width // want `avoid direct access to proto field hat\.Brim\.Width, use hat\.GetBrim\(\)\.GetWidth\(\) instead`
+
+	return hat, nil
+}
+
+func testValidTwirp(hat *twirp.Hat) {
+	_ = hat.GetSize()
+	_ = hat.GetBrim().GetWidth()
+	hat.Color = "blue"
+}
@@ -0,0 +1,32 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalidVTProto(t *proto.Test) {
+	_, _ = t.MarshalVT()
+	_ = t.Embedded.S // want `avoid direct access to proto field t\.Embedded\.S, use t\.GetEmbedded\(\)\.GetS\(\) instead`
+}
+
+func testInvalidVTPool() *proto.Embedded {
+	t := proto.TestFromVTPool()
+	defer t.ReturnToVTPool()
+
+	embedded := t.Embedded                  // want `proto field t\.Embedded is retained from a message returned to the pool with t\.ReturnToVTPool\(\), use t\.GetEmbedded\(\)\.CloneVT\(\) instead`
+	inner := t.GetEmbedded().GetEmbedded()  // want `proto field t\.GetEmbedded\(\)\.GetEmbedded\(\) is retained from a message returned to the pool with t\.ReturnToVTPool\(\), use t\.GetEmbedded\(\)\.GetEmbedded\(\)\.CloneVT\(\) instead`
+	var repeated = t.GetRepeatedEmbeddeds() // want `proto field t\.GetRepeatedEmbeddeds\(\) is retained from a message returned to the pool with t\.ReturnToVTPool\(\), copy it before returning the message to the pool`
+	_, _ = inner, repeated
+
+	return embedded
+}
+
+func testValidVTPool() string {
+	t := proto.TestFromVTPool()
+	defer t.ReturnToVTPool()
+
+	embedded := t.GetEmbedded().CloneVT()
+	_ = embedded
+
+	return t.GetS()
+}
@@ -0,0 +1,184 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Messages generated with protoc-gen-go-vtproto are regular protoc-gen-go messages with additional
+// *VT methods (MarshalVT, MarshalToSizedBufferVT, CloneVT, ...). They keep nil-safe getters, so they must
+// be checked as usual and must not be confused with gogo messages, which have MarshalToSizedBuffer.
+
+const (
+	vtPoolCloneMsgFormat = ruleVTPoolRetained + ": proto field %s is retained from a message returned to the pool with %s, use %s instead"
+	vtPoolCopyMsgFormat  = ruleVTPoolRetained + ": proto field %s is retained from a message returned to the pool with %s, copy it before returning the message to the pool"
+)
+
+const (
+	vtReturnToPoolMethod = "ReturnToVTPool"
+	vtCloneMethod        = "CloneVT"
+)
+
+// checkVTPool reports fields retained from messages that are returned to the vtprotobuf pool in the same function.
+// After ReturnToVTPool the message is reset and reused, so retained pointers, slices and maps may be overwritten.
+func checkVTPool(pass *analysis.Pass, filter *posFilter, cfg *Config, fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+
+	pooled := make(map[types.Object]string)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 0 {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != vtReturnToPoolMethod {
+			return true
+		}
+
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || !isProtoMessage(cfg, pass.TypesInfo, ident) {
+			return true
+		}
+
+		if obj := pass.TypesInfo.ObjectOf(ident); obj != nil {
+			pooled[obj] = formatNode(call)
+		}
+		return true
+	})
+
+	if len(pooled) == 0 {
+		return
+	}
+
+	checkRetained := func(expr ast.Expr) {
+		expr = ast.Unparen(expr)
+
+		root, ok := vtRetainedFieldRoot(cfg, pass.TypesInfo, expr)
+		if !ok {
+			return
+		}
+
+		returnCall, ok := pooled[pass.TypesInfo.ObjectOf(root)]
+		if !ok || !isReferenceType(pass.TypesInfo.TypeOf(expr)) {
+			return
+		}
+
+		result, err := processNode(pass.TypesInfo, filter, expr, cfg)
+		if err != nil {
+			return
+		}
+
+		// The retained expression is reported here, so skip it in the getters check.
+		filter.AddPos(expr.Pos())
+
+		if !methodIsExists(pass.TypesInfo, expr, vtCloneMethod) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     expr.Pos(),
+				End:     expr.End(),
+				Message: fmt.Sprintf(vtPoolCopyMsgFormat, formatNode(expr), returnCall),
+			})
+			return
+		}
+
+		to := result.To + "." + vtCloneMethod + "()"
+		msg := fmt.Sprintf(vtPoolCloneMsgFormat, formatNode(expr), returnCall, to)
+		pass.Report(analysis.Diagnostic{
+			Pos:     expr.Pos(),
+			End:     expr.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: append(slices.Clip(result.edits), analysis.TextEdit{
+						Pos:     expr.End(),
+						End:     expr.End(),
+						NewText: []byte("." + vtCloneMethod + "()"),
+					}),
+				},
+			},
+		})
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for _, rhs := range x.Rhs {
+				checkRetained(rhs)
+			}
+		case *ast.ValueSpec:
+			for _, v := range x.Values {
+				checkRetained(v)
+			}
+		}
+		return true
+	})
+}
+
+// vtRetainedFieldRoot returns the root identifier of a field read (m.Field, m.GetField() or m.A.GetB())
+// from a proto message.
+func vtRetainedFieldRoot(cfg *Config, info *types.Info, expr ast.Expr) (*ast.Ident, bool) {
+	var x ast.Expr
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		selection, ok := info.Selections[e]
+		if !ok || selection.Kind() != types.FieldVal {
+			return nil, false
+		}
+		x = e.X
+
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || len(e.Args) != 0 || !isGetterName(sel.Sel.Name) {
+			return nil, false
+		}
+		x = sel.X
+
+	default:
+		return nil, false
+	}
+
+	if !isProtoMessage(cfg, info, x) {
+		return nil, false
+	}
+
+	for {
+		switch e := x.(type) {
+		case *ast.Ident:
+			return e, true
+		case *ast.SelectorExpr:
+			x = e.X
+		case *ast.CallExpr:
+			x = e.Fun
+		case *ast.IndexExpr:
+			x = e.X
+		case *ast.ParenExpr:
+			x = e.X
+		default:
+			return nil, false
+		}
+	}
+}
+
+func isGetterName(name string) bool {
+	return len(name) > 3 && name[:3] == "Get"
+}
+
+func isReferenceType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	}
+
+	return false
+}